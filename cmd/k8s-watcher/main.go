@@ -6,13 +6,19 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
 	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+	"github.com/wasim-nihal/k8s-watcher/pkg/metrics"
 	"github.com/wasim-nihal/k8s-watcher/pkg/version"
 	"github.com/wasim-nihal/k8s-watcher/pkg/watcher"
 )
@@ -49,12 +55,29 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Create dynamic client for customresource watching
+	dynamicClient, err := createDynamicClient(cfg.Kubernetes)
+	if err != nil {
+		logger.Error("Failed to create dynamic client", "error", err)
+		os.Exit(1)
+	}
+
 	// Create and start watcher
-	w := watcher.NewWatcher(client, cfg)
+	w := watcher.NewWatcher(client, dynamicClient, cfg)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	var metricsServer *metrics.Server
+	if cfg.Metrics.Enabled {
+		metricsServer = metrics.NewServer(cfg.Metrics)
+		go func() {
+			if err := metricsServer.ListenAndServe(ctx); err != nil {
+				logger.Error("Metrics server failed", "error", err)
+			}
+		}()
+	}
+
 	// Handle shutdown gracefully
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
@@ -65,24 +88,121 @@ func main() {
 		cancel()
 	}()
 
+	if cfg.Kubernetes.LeaderElection.Enabled {
+		// Start in warm standby: the watcher's informers run (and keep
+		// their caches hot) immediately, but handleResource no-ops until
+		// this replica is promoted, so failover doesn't pay for a cold
+		// relist/cache rebuild.
+		w.SetLeading(false)
+		if metricsServer != nil {
+			metricsServer.SetLeader(false)
+		}
+		go runWithLeaderElection(ctx, client, cfg.Kubernetes.LeaderElection, w, metricsServer)
+	}
+
 	logger.Info("Starting k8s-watcher")
 	if err := w.Start(ctx); err != nil {
 		logger.Error("Watcher failed", "error", err)
 		os.Exit(1)
 	}
 
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := w.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to flush buffered notifications during shutdown", "error", err)
+	}
+	shutdownCancel()
+
 	logger.Info("Shutting down k8s-watcher")
 }
 
+// runWithLeaderElection races for the configured coordination.k8s.io Lease
+// and flips w (and, if configured, metricsServer's /readyz state) between
+// leading and warm-standby as the lease changes hands. The watcher's
+// informers are started by the caller independently of leadership, so a
+// standby replica already has a hot cache and can take over the moment it's
+// promoted. Unlike leaderelection.RunOrDie, the election is retried for as
+// long as ctx is alive instead of returning (and the process exiting) the
+// first time this replica loses the lease.
+func runWithLeaderElection(ctx context.Context, client kubernetes.Interface, cfg config.LeaderElectionConfig, w *watcher.Watcher, metricsServer *metrics.Server) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaseName,
+			Namespace: cfg.LeaseNamespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: cfg.Identity,
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   time.Duration(cfg.LeaseDuration) * time.Second,
+		RenewDeadline:   time.Duration(cfg.RenewDeadline) * time.Second,
+		RetryPeriod:     time.Duration(cfg.RetryPeriod) * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				logger.Info("Acquired leader lease", "identity", cfg.Identity)
+				w.SetLeading(true)
+				if metricsServer != nil {
+					metricsServer.SetLeader(true)
+				}
+			},
+			OnStoppedLeading: func() {
+				logger.Info("Lost leader lease, entering warm standby", "identity", cfg.Identity)
+				w.SetLeading(false)
+				if metricsServer != nil {
+					metricsServer.SetLeader(false)
+				}
+			},
+			OnNewLeader: func(identity string) {
+				if identity != cfg.Identity {
+					logger.Info("New leader elected", "identity", identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to create leader elector", "error", err)
+		return
+	}
+
+	for ctx.Err() == nil {
+		elector.Run(ctx)
+	}
+}
+
 // createKubernetesClient creates a Kubernetes client using the provided configuration
 func createKubernetesClient(cfg config.KubernetesConfig) (kubernetes.Interface, error) {
-	var config *rest.Config
+	restConfig, err := buildRestConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// createDynamicClient creates a dynamic Kubernetes client for arbitrary GVRs,
+// used for config.ResourceTypeCustomResource watching
+func createDynamicClient(cfg config.KubernetesConfig) (dynamic.Interface, error) {
+	restConfig, err := buildRestConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.NewForConfig(restConfig)
+}
+
+// buildRestConfig loads the rest.Config shared by the typed and dynamic clients
+func buildRestConfig(cfg config.KubernetesConfig) (*rest.Config, error) {
+	var restConfig *rest.Config
 	var err error
 
 	if cfg.Kubeconfig != "" {
-		config, err = clientcmd.BuildConfigFromFlags("", cfg.Kubeconfig)
+		restConfig, err = clientcmd.BuildConfigFromFlags("", cfg.Kubeconfig)
 	} else {
-		config, err = rest.InClusterConfig()
+		restConfig, err = rest.InClusterConfig()
 	}
 
 	if err != nil {
@@ -90,10 +210,10 @@ func createKubernetesClient(cfg config.KubernetesConfig) (kubernetes.Interface,
 	}
 
 	if cfg.SkipTLSVerify {
-		config.TLSClientConfig.Insecure = true
-		config.TLSClientConfig.CAData = nil
-		config.TLSClientConfig.CAFile = ""
+		restConfig.TLSClientConfig.Insecure = true
+		restConfig.TLSClientConfig.CAData = nil
+		restConfig.TLSClientConfig.CAFile = ""
 	}
 
-	return kubernetes.NewForConfig(config)
+	return restConfig, nil
 }
@@ -1,90 +1,51 @@
 package main
 
 import (
-	"encoding/base64"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
+	"context"
+	"flag"
 	"os"
-	"strings"
-	"time"
-)
+	"os/signal"
+	"syscall"
 
-const (
-	expectedUsername = "admin"
-	expectedPassword = "secret"
+	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+	"github.com/wasim-nihal/k8s-watcher/pkg/webhookserver"
 )
 
 func main() {
-	// Open log file
-	f, err := os.OpenFile("/tmp/webhooks.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	configPath := flag.String("config", "webhook-server.yaml", "Path to configuration file")
+	flag.Parse()
+
+	cfg, err := webhookserver.LoadConfig(*configPath)
 	if err != nil {
-		log.Fatalf("Error opening log file: %v", err)
+		logger.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
 	}
-	defer f.Close()
-
-	logger := log.New(f, "", log.LstdFlags)
-
-	http.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
-		// Check method
-		if r.Method != http.MethodPost {
-			w.WriteHeader(http.StatusMethodNotAllowed)
-			return
-		}
 
-		// Check basic auth
-		authHeader := r.Header.Get("Authorization")
-		if !validateBasicAuth(authHeader) {
-			w.Header().Set("WWW-Authenticate", `Basic realm="Test"`)
-			w.WriteHeader(http.StatusUnauthorized)
-			return
-		}
+	if err := logger.Initialize(cfg.Logging); err != nil {
+		logger.Error("Failed to initialize logger", "error", err)
+		os.Exit(1)
+	}
 
-		// Read body
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-		defer r.Body.Close()
+	logger.Info("Starting webhook-server", "listenAddr", cfg.ListenAddr, "path", cfg.Path)
 
-		// Log the webhook
-		logger.Printf("Received webhook at %s: %s\n", time.Now().Format(time.RFC3339), string(body))
+	srv := webhookserver.NewServer(*cfg)
 
-		// Respond with success
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	fmt.Println("Starting webhook server on http://localhost:8080")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
-		log.Fatalf("Server failed: %v", err)
-	}
-}
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
-func validateBasicAuth(authHeader string) bool {
-	if authHeader == "" {
-		return false
-	}
-
-	// Split "Basic <encoded>"
-	parts := strings.SplitN(authHeader, " ", 2)
-	if len(parts) != 2 || parts[0] != "Basic" {
-		return false
-	}
-
-	// Decode base64
-	decoded, err := base64.StdEncoding.DecodeString(parts[1])
-	if err != nil {
-		return false
-	}
+	go func() {
+		sig := <-signalChan
+		logger.Info("Received shutdown signal", "signal", sig)
+		cancel()
+	}()
 
-	// Split username:password
-	credentials := strings.SplitN(string(decoded), ":", 2)
-	if len(credentials) != 2 {
-		return false
+	if err := srv.ListenAndServe(ctx); err != nil {
+		logger.Error("Webhook server failed", "error", err)
+		os.Exit(1)
 	}
 
-	return credentials[0] == expectedUsername && credentials[1] == expectedPassword
+	logger.Info("Shutting down webhook-server")
 }
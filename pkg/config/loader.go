@@ -3,8 +3,12 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"regexp"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/template"
 )
 
 // Loader handles configuration loading and validation
@@ -47,6 +51,68 @@ func (l *Loader) validate(cfg *Config) error {
 		return err
 	}
 
+	if err := l.validateLeaderElection(&cfg.Kubernetes.LeaderElection); err != nil {
+		return err
+	}
+
+	if err := l.validateTemplate(&cfg.Output.Template); err != nil {
+		return err
+	}
+
+	if err := l.validateMetrics(&cfg.Metrics); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateTemplate parses the configured output template so a malformed
+// template fails at config load time rather than on the first event
+func (l *Loader) validateTemplate(cfg *TemplateConfig) error {
+	if cfg.Path == "" {
+		return nil
+	}
+
+	if _, err := template.ParseFile(cfg.Path, cfg.Engine); err != nil {
+		return fmt.Errorf("output.template: %w", err)
+	}
+
+	return nil
+}
+
+// validateMetrics checks the metrics server's listening certificate
+func (l *Loader) validateMetrics(cfg *MetricsConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") {
+		return fmt.Errorf("metrics.tls.certFile and metrics.tls.keyFile must be set together")
+	}
+
+	return nil
+}
+
+// validateLeaderElection validates leader election durations against the
+// k8s.io/client-go/tools/leaderelection requirement that
+// leaseDuration > renewDeadline > retryPeriod
+func (l *Loader) validateLeaderElection(cfg *LeaderElectionConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.LeaseDuration < 0 || cfg.RenewDeadline < 0 || cfg.RetryPeriod < 0 {
+		return fmt.Errorf("leaderElection durations cannot be negative")
+	}
+
+	if cfg.LeaseDuration != 0 && cfg.RenewDeadline != 0 && cfg.LeaseDuration <= cfg.RenewDeadline {
+		return fmt.Errorf("leaderElection.leaseDuration must be greater than renewDeadline")
+	}
+
+	if cfg.RenewDeadline != 0 && cfg.RetryPeriod != 0 && cfg.RenewDeadline <= cfg.RetryPeriod {
+		return fmt.Errorf("leaderElection.renewDeadline must be greater than retryPeriod")
+	}
+
 	return nil
 }
 
@@ -56,6 +122,14 @@ func (l *Loader) validateResources(cfg *ResourceConfig) error {
 	switch cfg.Type {
 	case ResourceTypeConfigMap, ResourceTypeSecret, ResourceTypeBoth:
 		// Valid type
+	case ResourceTypeCustomResource:
+		if err := l.validateCustomResource(&cfg.CustomResource); err != nil {
+			return err
+		}
+	case ResourceTypePodLogs:
+		if err := l.validatePodLogs(cfg); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("invalid resource type: %s", cfg.Type)
 	}
@@ -70,6 +144,10 @@ func (l *Loader) validateResources(cfg *ResourceConfig) error {
 		}
 	}
 
+	if err := l.validateTransforms(cfg.Transforms); err != nil {
+		return err
+	}
+
 	// Validate labels
 	if len(cfg.Labels) == 0 {
 		return fmt.Errorf("at least one label configuration is required")
@@ -87,31 +165,336 @@ func (l *Loader) validateResources(cfg *ResourceConfig) error {
 		if err := l.validateScript(label.Script); err != nil {
 			return fmt.Errorf("invalid script config for label '%s': %w", label.Name, err)
 		}
+
+		if err := l.validateRoutes(label.Routes); err != nil {
+			return fmt.Errorf("invalid routes for label '%s': %w", label.Name, err)
+		}
+
+		if err := l.validateMatchExpressions(label.MatchExpressions); err != nil {
+			return fmt.Errorf("invalid matchExpressions for label '%s': %w", label.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateMatchExpressions validates a label's set-based requirements:
+// Operator must be one of In/NotIn/Exists/DoesNotExist, Values is required
+// for In/NotIn and must be empty for Exists/DoesNotExist
+func (l *Loader) validateMatchExpressions(exprs []LabelSelectorRequirement) error {
+	for i, expr := range exprs {
+		if expr.Key == "" {
+			return fmt.Errorf("matchExpressions[%d].key is required", i)
+		}
+
+		switch expr.Operator {
+		case LabelSelectorOpIn, LabelSelectorOpNotIn:
+			if len(expr.Values) == 0 {
+				return fmt.Errorf("matchExpressions[%d]: values is required for operator %q", i, expr.Operator)
+			}
+		case LabelSelectorOpExists, LabelSelectorOpDoesNotExist:
+			if len(expr.Values) > 0 {
+				return fmt.Errorf("matchExpressions[%d]: values must be empty for operator %q", i, expr.Operator)
+			}
+		default:
+			return fmt.Errorf("matchExpressions[%d]: invalid operator %q", i, expr.Operator)
+		}
+	}
+	return nil
+}
+
+// validateRoutes validates a label's routing rules: every route must be
+// named, every nameGlob must be a syntactically valid path.Match pattern,
+// and no two routes may share the same namespace/nameGlob/annotation
+// selector (which would make resolution ambiguous). Two routes with
+// different selectors that happen to score the same specificity (e.g. two
+// annotation-only routes matching the same resource) are not rejected here;
+// pkg/router.Router.Resolve resolves that case deterministically by
+// first-listed-wins.
+func (l *Loader) validateRoutes(routes []RouteConfig) error {
+	seen := make(map[string]bool, len(routes))
+
+	for _, route := range routes {
+		if route.Name == "" {
+			return fmt.Errorf("route name is required")
+		}
+
+		if _, err := path.Match(route.NameGlob, ""); err != nil {
+			return fmt.Errorf("route '%s': invalid nameGlob %q: %w", route.Name, route.NameGlob, err)
+		}
+
+		key := route.Namespace + "\x00" + route.NameGlob + "\x00" + route.Annotation
+		if seen[key] {
+			return fmt.Errorf("route '%s': namespace=%q nameGlob=%q annotation=%q collides with another route", route.Name, route.Namespace, route.NameGlob, route.Annotation)
+		}
+		seen[key] = true
+
+		if err := l.validateScript(route.Script); err != nil {
+			return fmt.Errorf("route '%s': %w", route.Name, err)
+		}
+
+		if err := l.validateRequest(route.Request); err != nil {
+			return fmt.Errorf("route '%s': %w", route.Name, err)
+		}
 	}
 
 	return nil
 }
 
-// validateRequest validates webhook request configuration
+// validateTransforms compiles every rule's regex (so a malformed pattern
+// fails at config load time rather than on the first event) and checks
+// that the action is one pkg/transform understands and required fields
+// for that action are set.
+func (l *Loader) validateTransforms(transforms []TransformConfig) error {
+	for i, t := range transforms {
+		if _, err := regexp.Compile(t.Regex); err != nil {
+			return fmt.Errorf("transforms[%d]: invalid regex %q: %w", i, t.Regex, err)
+		}
+
+		switch t.Action {
+		case TransformActionKeep, TransformActionDrop:
+			// No additional fields required.
+		case TransformActionReplace, TransformActionHashMod:
+			if t.TargetLabel == "" {
+				return fmt.Errorf("transforms[%d]: target_label is required for action %q", i, t.Action)
+			}
+			if t.Action == TransformActionHashMod && t.Modulus == 0 {
+				return fmt.Errorf("transforms[%d]: modulus must be greater than zero for action %q", i, t.Action)
+			}
+		case TransformActionLabelMap:
+			// No additional fields required.
+		default:
+			return fmt.Errorf("transforms[%d]: invalid action %q", i, t.Action)
+		}
+	}
+
+	return nil
+}
+
+// validateCustomResource validates the GroupVersionResource(s) used for
+// customresource watching
+func (l *Loader) validateCustomResource(cfg *CustomResourceConfig) error {
+	for i, gvr := range cfg.GVRs() {
+		if gvr.Version == "" {
+			return fmt.Errorf("customResource.resources[%d].version is required", i)
+		}
+		if gvr.Resource == "" {
+			return fmt.Errorf("customResource.resources[%d].resource is required", i)
+		}
+		for j, field := range gvr.Fields {
+			if field == "" {
+				return fmt.Errorf("customResource.resources[%d].fields[%d] cannot be empty", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// validatePodLogs validates pod log streaming configuration
+func (l *Loader) validatePodLogs(cfg *ResourceConfig) error {
+	if cfg.Method == WatchMethodList {
+		return fmt.Errorf("podLogs does not support the LIST watch method")
+	}
+
+	if cfg.PodLogs.SinceSeconds < 0 || cfg.PodLogs.TailLines < 0 {
+		return fmt.Errorf("podLogs.sinceSeconds and podLogs.tailLines cannot be negative")
+	}
+
+	if cfg.PodLogs.MaxSizeBytes < 0 || cfg.PodLogs.MaxAgeSeconds < 0 {
+		return fmt.Errorf("podLogs.maxSizeBytes and podLogs.maxAgeSeconds cannot be negative")
+	}
+
+	if cfg.PodLogs.MaxConcurrentStreams < 0 {
+		return fmt.Errorf("podLogs.maxConcurrentStreams cannot be negative")
+	}
+
+	return nil
+}
+
+// validateRequest validates a label's notification configuration, dispatching
+// to the validation branch for the configured Transport
 func (l *Loader) validateRequest(cfg RequestConfig) error {
-	if cfg.URL != "" {
-		if cfg.Method != "" && cfg.Method != "GET" && cfg.Method != "POST" {
-			return fmt.Errorf("invalid request method: %s", cfg.Method)
+	transport := cfg.Transport
+	if transport == "" {
+		if cfg.URL == "" {
+			// No notification configured for this label.
+			return nil
 		}
+		transport = TransportHTTP
+	}
 
-		if cfg.Timeout < 0 {
-			return fmt.Errorf("timeout cannot be negative")
+	switch transport {
+	case TransportHTTP:
+		return l.validateHTTPRequest(cfg)
+	case TransportKafka:
+		return l.validateKafka(&cfg.Kafka)
+	case TransportNATS:
+		return l.validateNATS(&cfg.NATS)
+	case TransportGRPC:
+		return l.validateGRPC(&cfg.GRPC)
+	default:
+		return fmt.Errorf("invalid request transport: %s", cfg.Transport)
+	}
+}
+
+// validateHTTPRequest validates webhook request configuration for the http transport
+func (l *Loader) validateHTTPRequest(cfg RequestConfig) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("request.url is required for the http transport")
+	}
+
+	if cfg.Method != "" && cfg.Method != "GET" && cfg.Method != "POST" {
+		return fmt.Errorf("invalid request method: %s", cfg.Method)
+	}
+
+	if cfg.Timeout < 0 {
+		return fmt.Errorf("timeout cannot be negative")
+	}
+
+	if cfg.Retry.Total < 0 || cfg.Retry.Connect < 0 || cfg.Retry.Read < 0 {
+		return fmt.Errorf("retry counts cannot be negative")
+	}
+
+	if cfg.Retry.BackoffFactor < 1.0 {
+		return fmt.Errorf("backoff factor must be greater than or equal to 1.0")
+	}
+
+	if cfg.Retry.Base < 0 || cfg.Retry.Cap < 0 {
+		return fmt.Errorf("retry base/cap cannot be negative")
+	}
+
+	for _, code := range cfg.Retry.RetryOn {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid retry.retryOn status code: %d", code)
 		}
+	}
+
+	if err := l.validateAuth(&cfg.Auth); err != nil {
+		return err
+	}
+
+	if err := l.validateTLS(&cfg.TLS); err != nil {
+		return err
+	}
+
+	return l.validateBatch(&cfg.Batch)
+}
+
+// validateAuth validates the optional Bearer/OAuth2/HMAC auth settings
+func (l *Loader) validateAuth(cfg *AuthConfig) error {
+	if cfg.HMAC.Secret != "" && cfg.HMAC.Algorithm != "" && cfg.HMAC.Algorithm != HMACAlgorithmSHA256 {
+		return fmt.Errorf("unsupported auth.hmac.algorithm: %s", cfg.HMAC.Algorithm)
+	}
+
+	if cfg.OAuth2.TokenURL != "" && (cfg.OAuth2.ClientID == "" || cfg.OAuth2.ClientSecret == "") {
+		return fmt.Errorf("auth.oauth2.clientID and auth.oauth2.clientSecret are required when tokenURL is set")
+	}
+
+	return nil
+}
+
+// validateKafka validates kafka transport configuration
+func (l *Loader) validateKafka(cfg *KafkaConfig) error {
+	if len(cfg.Brokers) == 0 {
+		return fmt.Errorf("kafka.brokers is required")
+	}
+
+	if cfg.Topic == "" {
+		return fmt.Errorf("kafka.topic is required")
+	}
+
+	switch cfg.SASL.Mechanism {
+	case "", SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512:
+		// Valid
+	default:
+		return fmt.Errorf("invalid kafka.sasl.mechanism: %s", cfg.SASL.Mechanism)
+	}
+
+	return l.validateTLS(&cfg.TLS)
+}
+
+// validateNATS validates nats transport configuration
+func (l *Loader) validateNATS(cfg *NATSConfig) error {
+	if len(cfg.URLs) == 0 {
+		return fmt.Errorf("nats.urls is required")
+	}
+
+	if cfg.Subject == "" {
+		return fmt.Errorf("nats.subject is required")
+	}
+
+	return l.validateTLS(&cfg.TLS)
+}
+
+// validateGRPC validates grpc transport configuration
+func (l *Loader) validateGRPC(cfg *GRPCConfig) error {
+	if cfg.Target == "" {
+		return fmt.Errorf("grpc.target is required")
+	}
+
+	if cfg.Method == "" {
+		return fmt.Errorf("grpc.method is required")
+	}
+
+	return l.validateTLS(&cfg.TLS)
+}
+
+// validateBatch validates batch webhook delivery configuration
+func (l *Loader) validateBatch(cfg *BatchConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.MaxSize < 0 {
+		return fmt.Errorf("batch.maxSize cannot be negative")
+	}
+
+	if cfg.MaxWaitMs < 0 {
+		return fmt.Errorf("batch.maxWaitMs cannot be negative")
+	}
+
+	return nil
+}
+
+// validateTLS validates mutual TLS settings for an outbound webhook request
+func (l *Loader) validateTLS(cfg *TLSConfig) error {
+	switch cfg.ClientAuthType {
+	case "", ClientAuthTypeNone, ClientAuthTypeVerifyIfGiven, ClientAuthTypeRequireAndVerify:
+		// Valid
+	default:
+		return fmt.Errorf("invalid tls.clientAuthType: %s", cfg.ClientAuthType)
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return fmt.Errorf("tls.certFile and tls.keyFile must be set together")
+	}
 
-		if cfg.Retry.Total < 0 || cfg.Retry.Connect < 0 || cfg.Retry.Read < 0 {
-			return fmt.Errorf("retry counts cannot be negative")
+	if cfg.ClientAuthType == ClientAuthTypeRequireAndVerify && cfg.CertFile == "" {
+		return fmt.Errorf("tls.certFile and tls.keyFile are required when clientAuthType is require-and-verify")
+	}
+
+	if cfg.CertFile != "" {
+		if _, err := os.Stat(cfg.CertFile); err != nil {
+			return fmt.Errorf("tls.certFile: %w", err)
+		}
+		if _, err := os.Stat(cfg.KeyFile); err != nil {
+			return fmt.Errorf("tls.keyFile: %w", err)
 		}
+	}
 
-		if cfg.Retry.BackoffFactor < 1.0 {
-			return fmt.Errorf("backoff factor must be greater than or equal to 1.0")
+	if cfg.CAFile != "" {
+		if _, err := os.Stat(cfg.CAFile); err != nil {
+			return fmt.Errorf("tls.caFile: %w", err)
 		}
 	}
 
+	switch cfg.MinVersion {
+	case "", TLSMinVersion12, TLSMinVersion13:
+		// Valid
+	default:
+		return fmt.Errorf("invalid tls.minVersion: %s", cfg.MinVersion)
+	}
+
 	return nil
 }
 
@@ -133,6 +516,9 @@ func (l *Loader) setDefaults(cfg *Config) {
 		cfg.Output.FolderAnnotation = DefaultFolderAnnotation
 	}
 
+	// Leader election defaults
+	l.setLeaderElectionDefaults(&cfg.Kubernetes.LeaderElection, cfg.Kubernetes.Namespace)
+
 	// Watch config defaults
 	if cfg.Resources.WatchConfig.ServerTimeout == 0 {
 		cfg.Resources.WatchConfig.ServerTimeout = DefaultServerTimeout
@@ -143,12 +529,31 @@ func (l *Loader) setDefaults(cfg *Config) {
 	if cfg.Resources.WatchConfig.ErrorThrottleTime == 0 {
 		cfg.Resources.WatchConfig.ErrorThrottleTime = DefaultErrorThrottle
 	}
+	if cfg.Resources.WatchConfig.Workers == 0 {
+		cfg.Resources.WatchConfig.Workers = DefaultWorkers
+	}
+	if cfg.Resources.WatchConfig.MaxRetries == 0 {
+		cfg.Resources.WatchConfig.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.Resources.Type == ResourceTypePodLogs && cfg.Resources.PodLogs.MaxConcurrentStreams == 0 {
+		cfg.Resources.PodLogs.MaxConcurrentStreams = DefaultMaxLogStreams
+	}
 
 	// Set defaults for each label config
 	for i := range cfg.Resources.Labels {
 		l.setLabelDefaults(&cfg.Resources.Labels[i])
 	}
 
+	// Metrics defaults
+	if cfg.Metrics.Enabled {
+		if cfg.Metrics.ListenAddr == "" {
+			cfg.Metrics.ListenAddr = DefaultMetricsListenAddr
+		}
+		if cfg.Metrics.Path == "" {
+			cfg.Metrics.Path = DefaultMetricsPath
+		}
+	}
+
 	// Logging defaults
 	if cfg.Logging.Level == "" {
 		cfg.Logging.Level = DefaultLogLevel
@@ -161,29 +566,102 @@ func (l *Loader) setDefaults(cfg *Config) {
 	}
 }
 
+// setLeaderElectionDefaults sets default values for leader election configuration
+func (l *Loader) setLeaderElectionDefaults(cfg *LeaderElectionConfig, namespace string) {
+	if !cfg.Enabled {
+		return
+	}
+
+	if cfg.LeaseName == "" {
+		cfg.LeaseName = DefaultLeaseName
+	}
+	if cfg.LeaseNamespace == "" {
+		cfg.LeaseNamespace = namespace
+	}
+	if cfg.LeaseDuration == 0 {
+		cfg.LeaseDuration = DefaultLeaseDuration
+	}
+	if cfg.RenewDeadline == 0 {
+		cfg.RenewDeadline = DefaultRenewDeadline
+	}
+	if cfg.RetryPeriod == 0 {
+		cfg.RetryPeriod = DefaultRetryPeriod
+	}
+	if cfg.Identity == "" {
+		cfg.Identity = os.Getenv("POD_NAME")
+	}
+	if cfg.Identity == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			cfg.Identity = hostname
+		}
+	}
+}
+
 // setLabelDefaults sets default values for label-specific configuration
 func (l *Loader) setLabelDefaults(cfg *LabelConfig) {
-	if cfg.Request.URL != "" {
-		if cfg.Request.Method == "" {
-			cfg.Request.Method = "GET"
-		}
-		if cfg.Request.Timeout == 0 {
-			cfg.Request.Timeout = DefaultTimeout
-		}
-		if cfg.Request.Retry.Total == 0 {
-			cfg.Request.Retry.Total = DefaultRetryTotal
-		}
-		if cfg.Request.Retry.Connect == 0 {
-			cfg.Request.Retry.Connect = DefaultRetryConnect
+	setRequestDefaults(&cfg.Request)
+
+	for i := range cfg.Routes {
+		setRequestDefaults(&cfg.Routes[i].Request)
+	}
+}
+
+// setRequestDefaults applies http-transport defaults to a single
+// RequestConfig, shared by a label's own Request and each of its Routes'
+func setRequestDefaults(cfg *RequestConfig) {
+	transport := cfg.Transport
+	if transport == "" && cfg.URL != "" {
+		transport = TransportHTTP
+	}
+
+	if transport != TransportHTTP {
+		return
+	}
+
+	if cfg.Method == "" {
+		cfg.Method = "GET"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Retry.Total == 0 {
+		cfg.Retry.Total = DefaultRetryTotal
+	}
+	if cfg.Retry.Connect == 0 {
+		cfg.Retry.Connect = DefaultRetryConnect
+	}
+	if cfg.Retry.Read == 0 {
+		cfg.Retry.Read = DefaultRetryRead
+	}
+	if cfg.Retry.Base == 0 {
+		cfg.Retry.Base = cfg.Retry.BackoffFactor
+	}
+	if cfg.Retry.Base == 0 {
+		cfg.Retry.Base = DefaultRetryBase
+	}
+	if cfg.Retry.Cap == 0 {
+		cfg.Retry.Cap = DefaultRetryCap
+	}
+	if cfg.Retry.BackoffFactor == 0 {
+		cfg.Retry.BackoffFactor = DefaultBackoffFactor
+	}
+	if cfg.Auth.Basic.Encoding == "" {
+		cfg.Auth.Basic.Encoding = DefaultAuthEncoding
+	}
+	if cfg.Auth.HMAC.Secret != "" {
+		if cfg.Auth.HMAC.Header == "" {
+			cfg.Auth.HMAC.Header = DefaultHMACHeader
 		}
-		if cfg.Request.Retry.Read == 0 {
-			cfg.Request.Retry.Read = DefaultRetryRead
+		if cfg.Auth.HMAC.Algorithm == "" {
+			cfg.Auth.HMAC.Algorithm = DefaultHMACAlgorithm
 		}
-		if cfg.Request.Retry.BackoffFactor == 0 {
-			cfg.Request.Retry.BackoffFactor = DefaultBackoffFactor
+	}
+	if cfg.Batch.Enabled {
+		if cfg.Batch.MaxSize == 0 {
+			cfg.Batch.MaxSize = DefaultBatchMaxSize
 		}
-		if cfg.Request.Auth.Basic.Encoding == "" {
-			cfg.Request.Auth.Basic.Encoding = DefaultAuthEncoding
+		if cfg.Batch.MaxWaitMs == 0 {
+			cfg.Batch.MaxWaitMs = DefaultBatchMaxWaitMs
 		}
 	}
 }
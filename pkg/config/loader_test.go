@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTemplateFixture(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing template fixture: %v", err)
+	}
+	return path
+}
+
+func TestLoader_validateTemplate(t *testing.T) {
+	dir := t.TempDir()
+	goodPath := writeTemplateFixture(t, dir, "good.tmpl", "name={{ .Metadata.Name }}")
+	badPath := writeTemplateFixture(t, dir, "bad.tmpl", "name={{ .Metadata.Name")
+
+	tests := []struct {
+		name    string
+		cfg     TemplateConfig
+		wantErr bool
+	}{
+		{name: "no path is a no-op", cfg: TemplateConfig{}, wantErr: false},
+		{name: "valid template", cfg: TemplateConfig{Path: goodPath}, wantErr: false},
+		{name: "valid template with explicit engine", cfg: TemplateConfig{Path: goodPath, Engine: TemplateEngineSprig}, wantErr: false},
+		{name: "malformed template", cfg: TemplateConfig{Path: badPath}, wantErr: true},
+		{name: "unknown engine", cfg: TemplateConfig{Path: goodPath, Engine: "bogus"}, wantErr: true},
+		{name: "missing file", cfg: TemplateConfig{Path: filepath.Join(dir, "missing.tmpl")}, wantErr: true},
+	}
+
+	l := NewLoader("")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.validateTemplate(&tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,485 @@
+package config
+
+// Config represents the root configuration structure
+type Config struct {
+	Output     OutputConfig     `yaml:"output"`
+	Kubernetes KubernetesConfig `yaml:"kubernetes"`
+	Resources  ResourceConfig   `yaml:"resources"`
+	Logging    LoggingConfig    `yaml:"logging"`
+	Metrics    MetricsConfig    `yaml:"metrics"`
+}
+
+// MetricsConfig configures the Prometheus /metrics endpoint served
+// alongside the watcher
+type MetricsConfig struct {
+	Enabled    bool             `yaml:"enabled"`
+	ListenAddr string           `yaml:"listenAddr"`
+	Path       string           `yaml:"path"`
+	TLS        MetricsTLSConfig `yaml:"tls"`
+}
+
+// MetricsTLSConfig configures the metrics server's listening certificate
+type MetricsTLSConfig struct {
+	CertFile     string `yaml:"certFile"`
+	KeyFile      string `yaml:"keyFile"`
+	ClientCAFile string `yaml:"clientCAFile"`
+}
+
+// OutputConfig defines file output settings
+type OutputConfig struct {
+	Folder           string         `yaml:"folder"`
+	FolderAnnotation string         `yaml:"folderAnnotation"`
+	UniqueFilenames  bool           `yaml:"uniqueFilenames"`
+	DefaultFileMode  string         `yaml:"defaultFileMode"`
+	Template         TemplateConfig `yaml:"template"`
+}
+
+// TemplateConfig renders a watched resource through a Go template instead of
+// writing its keys verbatim, so users can produce arbitrary output formats
+// (Prometheus scrape configs, nginx snippets, Grafana provisioning YAML)
+type TemplateConfig struct {
+	Path             string `yaml:"path"`
+	Engine           string `yaml:"engine"`
+	FilenameTemplate string `yaml:"filenameTemplate"`
+}
+
+// Supported template engines
+const (
+	TemplateEngineGoTemplate = "gotemplate"
+	TemplateEngineSprig      = "sprig"
+)
+
+// KubernetesConfig defines Kubernetes connection settings
+type KubernetesConfig struct {
+	Kubeconfig     string               `yaml:"kubeconfig"`
+	Namespace      string               `yaml:"namespace"`
+	SkipTLSVerify  bool                 `yaml:"skipTLSVerify"`
+	LeaderElection LeaderElectionConfig `yaml:"leaderElection"`
+}
+
+// LeaderElectionConfig defines the leases.coordination.k8s.io-backed leader
+// election settings used to run k8s-watcher with multiple replicas safely
+type LeaderElectionConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	LeaseName      string `yaml:"leaseName"`
+	LeaseNamespace string `yaml:"leaseNamespace"`
+	LeaseDuration  int    `yaml:"leaseDuration"`
+	RenewDeadline  int    `yaml:"renewDeadline"`
+	RetryPeriod    int    `yaml:"retryPeriod"`
+	Identity       string `yaml:"identity"`
+}
+
+// ResourceConfig defines resource watching configuration
+type ResourceConfig struct {
+	Type           string               `yaml:"type"`
+	Method         string               `yaml:"method"`
+	ResourceNames  []string             `yaml:"resourceNames"`
+	CustomResource CustomResourceConfig `yaml:"customResource"`
+	PodLogs        PodLogsConfig        `yaml:"podLogs"`
+	WatchConfig    WatchConfig          `yaml:"watchConfig"`
+	// Transforms is a Prometheus relabel_config-style pipeline evaluated, in
+	// order, against every resource's name/namespace/labels/annotations
+	// before label matching and file writing happen. See pkg/transform.
+	Transforms []TransformConfig `yaml:"transforms"`
+	Labels     []LabelConfig     `yaml:"labels"`
+}
+
+// TransformConfig is a single relabeling rule. SourceLabels name the
+// labels/annotations whose values (joined with ";") are matched against
+// Regex; the well-known names __name__ and __namespace__ resolve to the
+// resource's name and namespace instead of a label lookup.
+//
+//   - keep:     continue only if the joined value matches Regex, else drop the event
+//   - drop:     drop the event if the joined value matches Regex
+//   - replace:  set annotation TargetLabel to Regex.ReplaceAllString(joined, Replacement)
+//   - hashmod:  set annotation TargetLabel to fnv64a(joined) % Modulus, base 10
+//   - labelmap: copy every label whose key matches Regex to an annotation
+//     named Regex.ReplaceAllString(key, Replacement)
+type TransformConfig struct {
+	SourceLabels []string `yaml:"source_labels"`
+	Action       string   `yaml:"action"`
+	Regex        string   `yaml:"regex"`
+	TargetLabel  string   `yaml:"target_label"`
+	Replacement  string   `yaml:"replacement"`
+	Modulus      uint64   `yaml:"modulus"`
+}
+
+// Valid TransformConfig.Action values
+const (
+	TransformActionKeep     = "keep"
+	TransformActionDrop     = "drop"
+	TransformActionReplace  = "replace"
+	TransformActionHashMod  = "hashmod"
+	TransformActionLabelMap = "labelmap"
+)
+
+// CustomResourceConfig identifies the GroupVersionResource(s) to watch when
+// Type is ResourceTypeCustomResource. A single resource may be configured
+// directly via Group/Version/Resource/Kind, or Resources may list several
+// GVRs to watch concurrently (e.g. Tekton PipelineRuns alongside Argo
+// Applications), each mirrored to disk through the same label/script/webhook
+// plumbing used for ConfigMaps and Secrets.
+type CustomResourceConfig struct {
+	Group     string      `yaml:"group"`
+	Version   string      `yaml:"version"`
+	Resource  string      `yaml:"resource"`
+	Kind      string      `yaml:"kind"`
+	Resources []GVRConfig `yaml:"resources"`
+}
+
+// GVRConfig identifies a single GroupVersionResource entry in
+// CustomResourceConfig.Resources
+type GVRConfig struct {
+	Group    string `yaml:"group"`
+	Version  string `yaml:"version"`
+	Resource string `yaml:"resource"`
+	Kind     string `yaml:"kind"`
+	// Namespace restricts this GVR to a single namespace, overriding the
+	// namespace(s) the rest of Resources.ResourceNames/Kubernetes.Namespace
+	// would otherwise watch. Empty means this entry follows the informer's
+	// usual namespace set.
+	Namespace string `yaml:"namespace"`
+	// Fields projects dotted paths (e.g. "spec", "data.config.yaml") out of
+	// the unstructured object into separate output files, one per entry,
+	// named after the path's last segment. Empty means the whole object is
+	// marshaled to a single <name>.yaml file.
+	Fields []string `yaml:"fields"`
+}
+
+// GVRs returns the configured list of GroupVersionResources to watch,
+// falling back to a single entry built from Group/Version/Resource/Kind when
+// Resources is empty
+func (c CustomResourceConfig) GVRs() []GVRConfig {
+	if len(c.Resources) > 0 {
+		return c.Resources
+	}
+	return []GVRConfig{{Group: c.Group, Version: c.Version, Resource: c.Resource, Kind: c.Kind}}
+}
+
+// PodLogsConfig configures container log tailing when Type is
+// ResourceTypePodLogs. Matched pods are those selected by Labels, same as
+// every other resource type.
+type PodLogsConfig struct {
+	// Container restricts streaming to a single container name; empty
+	// means every container in the matched pod is streamed.
+	Container    string `yaml:"container"`
+	SinceSeconds int64  `yaml:"sinceSeconds"`
+	TailLines    int64  `yaml:"tailLines"`
+	Previous     bool   `yaml:"previous"`
+	// MaxSizeBytes rotates the output log file once it would exceed this
+	// size; zero disables size-based rotation.
+	MaxSizeBytes int64 `yaml:"maxSizeBytes"`
+	// MaxAgeSeconds rotates the output log file once it has been open
+	// longer than this; zero disables age-based rotation.
+	MaxAgeSeconds int64 `yaml:"maxAgeSeconds"`
+	// MaxConcurrentStreams caps the number of container log streams open
+	// against the apiserver at once, defaulting to DefaultMaxLogStreams.
+	MaxConcurrentStreams int `yaml:"maxConcurrentStreams"`
+}
+
+// WatchConfig defines watch behavior settings
+type WatchConfig struct {
+	ServerTimeout     int  `yaml:"serverTimeout"`
+	ClientTimeout     int  `yaml:"clientTimeout"`
+	ErrorThrottleTime int  `yaml:"errorThrottleTime"`
+	IgnoreProcessed   bool `yaml:"ignoreProcessed"`
+	// Workers is the number of goroutines draining the event workqueue
+	Workers int `yaml:"workers"`
+	// MaxRetries is the number of times a failed event is retried (via
+	// queue.AddRateLimited) before it is dropped (queue.Forget)
+	MaxRetries int `yaml:"maxRetries"`
+}
+
+// LabelConfig defines label selection and actions
+type LabelConfig struct {
+	Name    string        `yaml:"name"`
+	Value   string        `yaml:"value"`
+	Script  ScriptConfig  `yaml:"script"`
+	Request RequestConfig `yaml:"request"`
+
+	// MatchExpressions adds set-based requirements alongside Name/Value,
+	// mirroring metav1.LabelSelector.MatchExpressions. A resource must
+	// satisfy Name/Value (if Name is set) and every expression here to
+	// match.
+	MatchExpressions []LabelSelectorRequirement `yaml:"matchExpressions"`
+
+	// Routes dispatches resources matched by this label to different
+	// Script/Request targets chosen by namespace, resource name glob and/or
+	// annotation, instead of always running Script/Request above. Resolution
+	// is most-specific-match-wins (see pkg/router); include a catch-all
+	// Route (every selector left empty) to handle resources no other Route
+	// matches. Script/Request above are ignored once Routes is non-empty.
+	Routes []RouteConfig `yaml:"routes"`
+}
+
+// LabelSelectorRequirement is a single set-based label requirement, mirroring
+// metav1.LabelSelectorRequirement. Values is required for In/NotIn and must
+// be empty for Exists/DoesNotExist.
+type LabelSelectorRequirement struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values"`
+}
+
+// Valid LabelSelectorRequirement.Operator values
+const (
+	LabelSelectorOpIn           = "In"
+	LabelSelectorOpNotIn        = "NotIn"
+	LabelSelectorOpExists       = "Exists"
+	LabelSelectorOpDoesNotExist = "DoesNotExist"
+)
+
+// RouteConfig selects a Script/Request target for resources matching a
+// namespace, resource name glob and/or annotation. Modeled on the host+path
+// handler maps used by reverse proxies.
+type RouteConfig struct {
+	Name string `yaml:"name"`
+	// Namespace, if set, must equal the resource's namespace exactly.
+	Namespace string `yaml:"namespace"`
+	// NameGlob, if set, is matched against the resource name with path.Match.
+	NameGlob string `yaml:"nameGlob"`
+	// Annotation, if set, is either "key" (must exist) or "key=value" (must
+	// equal value), matched against the resource's annotations.
+	Annotation string        `yaml:"annotation"`
+	Script     ScriptConfig  `yaml:"script"`
+	Request    RequestConfig `yaml:"request"`
+}
+
+// ScriptConfig defines script execution settings
+type ScriptConfig struct {
+	Path    string `yaml:"path"`
+	Timeout int    `yaml:"timeout"`
+}
+
+// RequestConfig defines webhook configuration
+type RequestConfig struct {
+	URL           string      `yaml:"url"`
+	Method        string      `yaml:"method"`
+	Payload       interface{} `yaml:"payload"`
+	Timeout       float64     `yaml:"timeout"`
+	Retry         RetryConfig `yaml:"retry"`
+	Auth          AuthConfig  `yaml:"auth"`
+	TLS           TLSConfig   `yaml:"tls"`
+	Batch         BatchConfig `yaml:"batch"`
+	SkipTLSVerify bool        `yaml:"skipTLSVerify"`
+
+	// Transport selects which notifier delivers this label's events, one of
+	// TransportHTTP (the default), TransportKafka, TransportNATS, or
+	// TransportGRPC. URL/Method/Retry/Auth/Batch above only apply to
+	// TransportHTTP; the other transports are configured by their own
+	// sub-struct below.
+	Transport string      `yaml:"transport"`
+	Kafka     KafkaConfig `yaml:"kafka"`
+	NATS      NATSConfig  `yaml:"nats"`
+	GRPC      GRPCConfig  `yaml:"grpc"`
+}
+
+// KafkaConfig configures the kafka transport, used when Transport is TransportKafka
+type KafkaConfig struct {
+	Brokers []string   `yaml:"brokers"`
+	Topic   string     `yaml:"topic"`
+	TLS     TLSConfig  `yaml:"tls"`
+	SASL    SASLConfig `yaml:"sasl"`
+}
+
+// SASLConfig configures SASL authentication for the kafka transport
+type SASLConfig struct {
+	Mechanism string `yaml:"mechanism"`
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+}
+
+// NATSConfig configures the nats transport, used when Transport is TransportNATS
+type NATSConfig struct {
+	URLs    []string  `yaml:"urls"`
+	Subject string    `yaml:"subject"`
+	TLS     TLSConfig `yaml:"tls"`
+}
+
+// GRPCConfig configures the grpc transport, used when Transport is
+// TransportGRPC. Method is the full gRPC method name (e.g.
+// "/pkg.Notifications/Notify") invoked with a google.protobuf.Struct built
+// from the event, since k8s-watcher does not vendor the downstream service's
+// generated stubs.
+type GRPCConfig struct {
+	Target string    `yaml:"target"`
+	Method string    `yaml:"method"`
+	TLS    TLSConfig `yaml:"tls"`
+}
+
+// BatchConfig coalesces multiple webhook notifications into a single HTTP
+// request, so a burst of resource events doesn't fire one request per event
+// against a slow downstream receiver
+type BatchConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxSize flushes the batch once this many items are buffered, defaulting
+	// to DefaultBatchMaxSize.
+	MaxSize int `yaml:"maxSize"`
+	// MaxWaitMs flushes a partial batch after this many milliseconds even if
+	// MaxSize hasn't been reached, defaulting to DefaultBatchMaxWaitMs.
+	MaxWaitMs int `yaml:"maxWaitMs"`
+	// FlushOnShutdown drains any buffered items when the watcher shuts down
+	FlushOnShutdown bool `yaml:"flushOnShutdown"`
+}
+
+// TLSConfig configures mutual TLS for an outbound webhook request, so
+// k8s-watcher can authenticate itself with a workload identity certificate
+// instead of (or in addition to) a shared password
+type TLSConfig struct {
+	CertFile   string `yaml:"certFile"`
+	KeyFile    string `yaml:"keyFile"`
+	CAFile     string `yaml:"caFile"`
+	ServerName string `yaml:"serverName"`
+	MinVersion string `yaml:"minVersion"`
+	// ClientAuthType controls whether presenting a client certificate is
+	// optional (verify-if-given, the default) or mandatory (require-and-verify)
+	ClientAuthType string `yaml:"clientAuthType"`
+}
+
+// RetryConfig defines retry behavior for HTTP requests
+type RetryConfig struct {
+	Total         int     `yaml:"total"`
+	Connect       int     `yaml:"connect"`
+	Read          int     `yaml:"read"`
+	BackoffFactor float64 `yaml:"backoffFactor"`
+
+	// Base and Cap configure full-jitter exponential backoff between retry
+	// attempts, in seconds: sleep = rand(0, min(Cap, Base*2^attempt)). Base
+	// defaults to BackoffFactor (so existing configs keep their current
+	// pacing) and falls back to DefaultRetryBase if that is also unset; Cap
+	// defaults to DefaultRetryCap. A Retry-After response header always
+	// takes precedence over the computed delay.
+	Base float64 `yaml:"base"`
+	Cap  float64 `yaml:"cap"`
+
+	// RetryOn lists the HTTP status codes that trigger a retry, defaulting
+	// to 429 and every 5xx when empty. A transport-level error (no
+	// response received) is always retried.
+	RetryOn []int `yaml:"retryOn"`
+
+	// RetryNonIdempotent allows retrying methods other than
+	// GET/HEAD/OPTIONS/PUT/DELETE/TRACE; such requests are otherwise never
+	// retried, even on a retryable status, since a retry could duplicate
+	// their side effect.
+	RetryNonIdempotent bool `yaml:"retryNonIdempotent"`
+}
+
+// AuthConfig defines authentication settings. At most one of Basic, Bearer
+// or OAuth2 should be configured; HMAC is independent and signs the request
+// body regardless of which of those is used.
+type AuthConfig struct {
+	Basic        BasicAuth `yaml:"basic"`
+	UsernameFile string    `yaml:"usernameFile"`
+	PasswordFile string    `yaml:"passwordFile"`
+
+	Bearer BearerAuth `yaml:"bearer"`
+	OAuth2 OAuth2Auth `yaml:"oauth2"`
+	HMAC   HMACAuth   `yaml:"hmac"`
+}
+
+// BasicAuth defines basic authentication credentials
+type BasicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Encoding string `yaml:"encoding"`
+}
+
+// BearerAuth sets a static Authorization: Bearer header
+type BearerAuth struct {
+	Token string `yaml:"token"`
+}
+
+// OAuth2Auth fetches a Bearer token via the OAuth2 client-credentials grant,
+// caching it until it expires
+type OAuth2Auth struct {
+	TokenURL     string   `yaml:"tokenURL"`
+	ClientID     string   `yaml:"clientID"`
+	ClientSecret string   `yaml:"clientSecret"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+// HMACAuth signs the request body with an HMAC and sends the signature in
+// Header, as e.g. GitHub/Stripe webhook receivers expect
+type HMACAuth struct {
+	Secret string `yaml:"secret"`
+	// Header defaults to DefaultHMACHeader ("X-Hub-Signature-256")
+	Header string `yaml:"header"`
+	// Algorithm is the only supported value today: HMACAlgorithmSHA256
+	Algorithm string `yaml:"algorithm"`
+}
+
+// LoggingConfig defines logging settings
+type LoggingConfig struct {
+	Level      string `yaml:"level"`
+	Format     string `yaml:"format"`
+	Timezone   string `yaml:"timezone"`
+	ConfigPath string `yaml:"configPath"`
+}
+
+// Constants for configuration defaults and supported values
+const (
+	// Resource types
+	ResourceTypeConfigMap      = "configmap"
+	ResourceTypeSecret         = "secret"
+	ResourceTypeBoth           = "both"
+	ResourceTypeCustomResource = "customresource"
+	ResourceTypePodLogs        = "pod-logs"
+
+	// TLS client auth types for RequestConfig.TLS.ClientAuthType
+	ClientAuthTypeNone             = "none"
+	ClientAuthTypeVerifyIfGiven    = "verify-if-given"
+	ClientAuthTypeRequireAndVerify = "require-and-verify"
+
+	// TLS minimum versions for RequestConfig.TLS.MinVersion
+	TLSMinVersion12 = "1.2"
+	TLSMinVersion13 = "1.3"
+
+	// Notification transports for RequestConfig.Transport
+	TransportHTTP  = "http"
+	TransportKafka = "kafka"
+	TransportNATS  = "nats"
+	TransportGRPC  = "grpc"
+
+	// SASL mechanisms for KafkaConfig.SASL.Mechanism
+	SASLMechanismPlain       = "plain"
+	SASLMechanismScramSHA256 = "scram-sha-256"
+	SASLMechanismScramSHA512 = "scram-sha-512"
+
+	// Watch methods
+	WatchMethodWatch = "WATCH"
+	WatchMethodList  = "LIST"
+	WatchMethodSleep = "SLEEP"
+
+	// Default values
+	DefaultFolderAnnotation  = "k8s-sidecar-target-directory"
+	DefaultServerTimeout     = 60
+	DefaultClientTimeout     = 66
+	DefaultErrorThrottle     = 5
+	DefaultWorkers           = 2
+	DefaultMaxRetries        = 5
+	DefaultLeaseName         = "k8s-watcher-leader"
+	DefaultLeaseDuration     = 15
+	DefaultRenewDeadline     = 10
+	DefaultRetryPeriod       = 2
+	DefaultRetryTotal        = 5
+	DefaultRetryConnect      = 10
+	DefaultRetryRead         = 5
+	DefaultBackoffFactor     = 1.1
+	DefaultTimeout           = 10.0
+	DefaultAuthEncoding      = "latin1"
+	DefaultLogLevel          = "INFO"
+	DefaultLogFormat         = "JSON"
+	DefaultLogTimezone       = "LOCAL"
+	DefaultMaxLogStreams     = 10
+	DefaultBatchMaxSize      = 50
+	DefaultBatchMaxWaitMs    = 1000
+	DefaultMetricsListenAddr = ":9090"
+	DefaultMetricsPath       = "/metrics"
+	DefaultRetryBase         = 1.0
+	DefaultRetryCap          = 30.0
+	DefaultHMACHeader        = "X-Hub-Signature-256"
+	DefaultHMACAlgorithm     = HMACAlgorithmSHA256
+
+	// HMAC algorithms for AuthConfig.HMAC.Algorithm
+	HMACAlgorithmSHA256 = "sha256"
+)
@@ -150,6 +150,39 @@ logging:
 			},
 			wantErr: false,
 		},
+		{
+			name: "output template config",
+			yaml: `
+output:
+  folder: /data
+  template:
+    path: /templates/config.tmpl
+    engine: sprig
+    filenameTemplate: "{{ .Key }}.conf"
+resources:
+  type: configmap
+  method: WATCH`,
+			want: Config{
+				Output: OutputConfig{
+					Folder: "/data",
+					Template: TemplateConfig{
+						Path:             "/templates/config.tmpl",
+						Engine:           "sprig",
+						FilenameTemplate: "{{ .Key }}.conf",
+					},
+				},
+				Resources: ResourceConfig{
+					Type:   ResourceTypeConfigMap,
+					Method: WatchMethodWatch,
+					WatchConfig: WatchConfig{
+						ServerTimeout:     DefaultServerTimeout,
+						ClientTimeout:     DefaultClientTimeout,
+						ErrorThrottleTime: DefaultErrorThrottle,
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid resource type",
 			yaml: `
@@ -245,7 +278,8 @@ func configEquals(a, b Config) bool {
 	if a.Output.Folder != b.Output.Folder ||
 		a.Output.FolderAnnotation != b.Output.FolderAnnotation ||
 		a.Output.UniqueFilenames != b.Output.UniqueFilenames ||
-		a.Output.DefaultFileMode != b.Output.DefaultFileMode {
+		a.Output.DefaultFileMode != b.Output.DefaultFileMode ||
+		a.Output.Template != b.Output.Template {
 		return false
 	}
 
@@ -294,6 +328,46 @@ func stringSliceEqual(a, b []string) bool {
 	return true
 }
 
+// gvrConfigEqual compares two GVRConfig values field by field, since Fields
+// is a slice and makes the struct non-comparable with ==.
+func gvrConfigEqual(a, b GVRConfig) bool {
+	return a.Group == b.Group &&
+		a.Version == b.Version &&
+		a.Resource == b.Resource &&
+		a.Kind == b.Kind &&
+		a.Namespace == b.Namespace &&
+		stringSliceEqual(a.Fields, b.Fields)
+}
+
+func TestCustomResourceConfig_GVRs(t *testing.T) {
+	t.Run("falls back to the flat fields when Resources is empty", func(t *testing.T) {
+		cfg := CustomResourceConfig{Group: "tekton.dev", Version: "v1beta1", Resource: "pipelineruns", Kind: "PipelineRun"}
+		want := []GVRConfig{{Group: "tekton.dev", Version: "v1beta1", Resource: "pipelineruns", Kind: "PipelineRun"}}
+
+		if got := cfg.GVRs(); len(got) != 1 || !gvrConfigEqual(got[0], want[0]) {
+			t.Errorf("GVRs() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("returns Resources verbatim when set", func(t *testing.T) {
+		resources := []GVRConfig{
+			{Group: "tekton.dev", Version: "v1beta1", Resource: "pipelineruns", Kind: "PipelineRun"},
+			{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications", Kind: "Application"},
+		}
+		cfg := CustomResourceConfig{Group: "ignored", Resources: resources}
+
+		got := cfg.GVRs()
+		if len(got) != len(resources) {
+			t.Fatalf("GVRs() returned %d entries, want %d", len(got), len(resources))
+		}
+		for i := range resources {
+			if !gvrConfigEqual(got[i], resources[i]) {
+				t.Errorf("GVRs()[%d] = %v, want %v", i, got[i], resources[i])
+			}
+		}
+	})
+}
+
 func BenchmarkConfigUnmarshal(b *testing.B) {
 	yamlData := []byte(`
 output:
@@ -14,7 +14,7 @@ func (r *ResourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 	// Validate resource type
 	switch r.Type {
-	case ResourceTypeConfigMap, ResourceTypeSecret, ResourceTypeBoth:
+	case ResourceTypeConfigMap, ResourceTypeSecret, ResourceTypeBoth, ResourceTypeCustomResource, ResourceTypePodLogs:
 		// Valid type
 	default:
 		return fmt.Errorf("invalid resource type: %s", r.Type)
@@ -30,6 +30,15 @@ func (r *ResourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	if r.WatchConfig.ErrorThrottleTime == 0 {
 		r.WatchConfig.ErrorThrottleTime = DefaultErrorThrottle
 	}
+	if r.WatchConfig.Workers == 0 {
+		r.WatchConfig.Workers = DefaultWorkers
+	}
+	if r.WatchConfig.MaxRetries == 0 {
+		r.WatchConfig.MaxRetries = DefaultMaxRetries
+	}
+	if r.Type == ResourceTypePodLogs && r.PodLogs.MaxConcurrentStreams == 0 {
+		r.PodLogs.MaxConcurrentStreams = DefaultMaxLogStreams
+	}
 
 	return nil
 }
@@ -1,10 +1,14 @@
 package file
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
 	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
@@ -13,11 +17,18 @@ import (
 // Handler manages file operations for the watcher
 type Handler struct {
 	config config.OutputConfig
+
+	versionsMu sync.RWMutex
+	// versions tracks, per output path, the last resourceVersion written
+	// through WriteFileIfNewer (or a content hash for paths written
+	// through WriteFile, or when no resourceVersion was supplied), so a
+	// late or duplicate informer event can never overwrite a newer write.
+	versions map[string]string
 }
 
 // NewHandler creates a new file handler
 func NewHandler(config config.OutputConfig) *Handler {
-	return &Handler{config: config}
+	return &Handler{config: config, versions: make(map[string]string)}
 }
 
 // GetDefaultPath returns the default output path
@@ -25,8 +36,66 @@ func (h *Handler) GetDefaultPath() string {
 	return h.config.Folder
 }
 
-// WriteFile writes data to a file with proper permissions
+// WriteFile atomically writes data to path (via a temp file in the same
+// directory plus os.Rename, so a reader never observes a partial write) and
+// records its content hash, so a later WriteFileIfNewer call for the same
+// path can tell whether the content actually changed.
 func (h *Handler) WriteFile(path string, data []byte) error {
+	if err := h.writeAtomic(path, data); err != nil {
+		return err
+	}
+
+	h.versionsMu.Lock()
+	h.versions[path] = contentHash(data)
+	h.versionsMu.Unlock()
+
+	logger.Info("File written successfully",
+		"path", path,
+		"size", len(data),
+	)
+
+	return nil
+}
+
+// WriteFileIfNewer writes data to path the same way WriteFile does, but
+// skips the write when rv is not newer than the version last recorded for
+// path. rv is compared numerically when both the stored and incoming
+// values parse as integers (the common case for Kubernetes
+// resourceVersions); otherwise, and whenever rv is empty, a sha256 of data
+// stands in for it, so unchanged content is still a no-op. This mirrors the
+// optimistic-concurrency check etcd3's GuaranteedUpdate performs before a
+// write.
+func (h *Handler) WriteFileIfNewer(path string, data []byte, rv string) (wrote bool, err error) {
+	version := rv
+	if version == "" {
+		version = contentHash(data)
+	}
+
+	h.versionsMu.Lock()
+	defer h.versionsMu.Unlock()
+
+	if stored, ok := h.versions[path]; ok && !isNewerVersion(stored, version) {
+		return false, nil
+	}
+
+	if err := h.writeAtomic(path, data); err != nil {
+		return false, err
+	}
+	h.versions[path] = version
+
+	logger.Info("File written successfully",
+		"path", path,
+		"size", len(data),
+		"resourceVersion", rv,
+	)
+
+	return true, nil
+}
+
+// writeAtomic writes data to a temp file alongside path and renames it into
+// place, so concurrent readers and writers of the same path never observe a
+// torn write.
+func (h *Handler) writeAtomic(path string, data []byte) error {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("creating directory %s: %w", dir, err)
@@ -37,19 +106,56 @@ func (h *Handler) WriteFile(path string, data []byte) error {
 		return err
 	}
 
-	if err := os.WriteFile(path, data, mode); err != nil {
-		return fmt.Errorf("writing file %s: %w", path, err)
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file in %s: %w", dir, err)
 	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once renamed into place
 
-	logger.Info("File written successfully",
-		"path", path,
-		"size", len(data),
-		"mode", mode,
-	)
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temp file %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return fmt.Errorf("setting mode on temp file %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("renaming %s to %s: %w", tmpPath, path, err)
+	}
 
 	return nil
 }
 
+// isNewerVersion reports whether incoming should replace stored. Equal
+// values are never newer; when both parse as unsigned integers (true for
+// real Kubernetes resourceVersions) they are compared numerically, so a
+// resync of a stale watch cache can't clobber a newer write. Otherwise any
+// different value (e.g. a changed content hash) is treated as newer.
+func isNewerVersion(stored, incoming string) bool {
+	if stored == incoming {
+		return false
+	}
+
+	storedN, storedErr := strconv.ParseUint(stored, 10, 64)
+	incomingN, incomingErr := strconv.ParseUint(incoming, 10, 64)
+	if storedErr == nil && incomingErr == nil {
+		return incomingN > storedN
+	}
+
+	return true
+}
+
+// contentHash returns the hex-encoded sha256 of data, used as a stand-in
+// version when no resourceVersion is available.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // DeleteFile removes a file from the filesystem
 func (h *Handler) DeleteFile(path string) error {
 	if err := os.Remove(path); err != nil {
@@ -60,10 +166,87 @@ func (h *Handler) DeleteFile(path string) error {
 		return nil
 	}
 
+	h.versionsMu.Lock()
+	delete(h.versions, path)
+	h.versionsMu.Unlock()
+
 	logger.Info("File deleted successfully", "path", path)
 	return nil
 }
 
+// SyncDir removes every file under the namespace's output directory that is
+// not present in keep (a set of full output paths, as produced by
+// GetOutputPath/GetAnnotationPath), then prunes any directories left empty
+// by that removal. It is a no-op if the directory doesn't exist yet. LIST
+// mode watchers call this after processing a full listing, so resources
+// deleted while the watcher wasn't running are still cleaned up.
+func (h *Handler) SyncDir(namespace string, keep map[string]struct{}) error {
+	dir := filepath.Join(h.config.Folder, namespace)
+
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("stat %s: %w", dir, err)
+	}
+
+	var stale []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := keep[path]; !ok {
+			stale = append(stale, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walking %s: %w", dir, err)
+	}
+
+	for _, path := range stale {
+		if err := h.DeleteFile(path); err != nil {
+			return err
+		}
+	}
+
+	return pruneEmptyDirs(dir)
+}
+
+// pruneEmptyDirs removes every empty directory under (but not including) dir
+func pruneEmptyDirs(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		sub := filepath.Join(dir, entry.Name())
+		if err := pruneEmptyDirs(sub); err != nil {
+			return err
+		}
+
+		remaining, err := os.ReadDir(sub)
+		if err != nil {
+			return fmt.Errorf("reading directory %s: %w", sub, err)
+		}
+		if len(remaining) == 0 {
+			if err := os.Remove(sub); err != nil {
+				return fmt.Errorf("removing empty directory %s: %w", sub, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // getFileMode returns the file mode from configuration
 func (h *Handler) getFileMode() (os.FileMode, error) {
 	if h.config.DefaultFileMode == "" {
@@ -336,6 +336,164 @@ func BenchmarkGetOutputPath(b *testing.B) {
 	}
 }
 
+func TestWriteFile_Atomic(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "file-handler-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	handler := file.NewHandler(config.OutputConfig{Folder: tempDir})
+	path := filepath.Join(tempDir, "cm", "data.txt")
+
+	if err := handler.WriteFile(path, []byte("first")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := handler.WriteFile(path, []byte("second")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading written file: %v", err)
+	}
+	if string(got) != "second" {
+		t.Errorf("file content = %q, want %q", got, "second")
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("reading directory: %v", err)
+	}
+	for _, e := range entries {
+		if e.Name() != "data.txt" {
+			t.Errorf("unexpected leftover entry %q, temp files should be renamed away", e.Name())
+		}
+	}
+}
+
+func TestWriteFileIfNewer(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "file-handler-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	handler := file.NewHandler(config.OutputConfig{Folder: tempDir})
+	path := filepath.Join(tempDir, "cm", "data.txt")
+
+	wrote, err := handler.WriteFileIfNewer(path, []byte("v1"), "10")
+	if err != nil {
+		t.Fatalf("WriteFileIfNewer() error = %v", err)
+	}
+	if !wrote {
+		t.Error("expected first write to succeed")
+	}
+
+	wrote, err = handler.WriteFileIfNewer(path, []byte("v0"), "5")
+	if err != nil {
+		t.Fatalf("WriteFileIfNewer() error = %v", err)
+	}
+	if wrote {
+		t.Error("expected an older resourceVersion to be skipped")
+	}
+
+	wrote, err = handler.WriteFileIfNewer(path, []byte("v2"), "20")
+	if err != nil {
+		t.Fatalf("WriteFileIfNewer() error = %v", err)
+	}
+	if !wrote {
+		t.Error("expected a newer resourceVersion to be written")
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading written file: %v", err)
+	}
+	if string(got) != "v2" {
+		t.Errorf("file content = %q, want %q", got, "v2")
+	}
+}
+
+func TestWriteFileIfNewer_ContentHashWhenNoResourceVersion(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "file-handler-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	handler := file.NewHandler(config.OutputConfig{Folder: tempDir})
+	path := filepath.Join(tempDir, "data.txt")
+
+	if _, err := handler.WriteFileIfNewer(path, []byte("same"), ""); err != nil {
+		t.Fatalf("WriteFileIfNewer() error = %v", err)
+	}
+
+	wrote, err := handler.WriteFileIfNewer(path, []byte("same"), "")
+	if err != nil {
+		t.Fatalf("WriteFileIfNewer() error = %v", err)
+	}
+	if wrote {
+		t.Error("expected identical content to be skipped as not newer")
+	}
+
+	wrote, err = handler.WriteFileIfNewer(path, []byte("different"), "")
+	if err != nil {
+		t.Fatalf("WriteFileIfNewer() error = %v", err)
+	}
+	if !wrote {
+		t.Error("expected changed content to be written")
+	}
+}
+
+func TestSyncDir(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "file-handler-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	handler := file.NewHandler(config.OutputConfig{Folder: tempDir})
+
+	keepPath := filepath.Join(tempDir, "default", "keep-me", "data.txt")
+	stalePath := filepath.Join(tempDir, "default", "stale", "data.txt")
+
+	if err := handler.WriteFile(keepPath, []byte("keep")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := handler.WriteFile(stalePath, []byte("stale")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	err = handler.SyncDir("default", map[string]struct{}{keepPath: {}})
+	if err != nil {
+		t.Fatalf("SyncDir() error = %v", err)
+	}
+
+	if !fileExists(keepPath) {
+		t.Error("expected the kept file to remain")
+	}
+	if fileExists(stalePath) {
+		t.Error("expected the stale file to be removed")
+	}
+	if fileExists(filepath.Dir(stalePath)) {
+		t.Error("expected the now-empty stale directory to be pruned")
+	}
+}
+
+func TestSyncDir_MissingDirectory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "file-handler-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	handler := file.NewHandler(config.OutputConfig{Folder: tempDir})
+	if err := handler.SyncDir("does-not-exist", nil); err != nil {
+		t.Errorf("SyncDir() on a missing directory should be a no-op, got error = %v", err)
+	}
+}
+
 // Helper function to check if a file exists
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
@@ -0,0 +1,106 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RotatingWriter appends lines to a log file, rotating the file to a ".1"
+// backup (replacing any prior backup) once it exceeds a configured size or
+// age. It is used for pod log tailing, where the watcher only ever appends
+// rather than rewriting the whole file.
+type RotatingWriter struct {
+	path          string
+	maxSizeBytes  int64
+	maxAgeSeconds int64
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingWriter opens path for appending, creating parent directories as
+// needed. maxSizeBytes and maxAgeSeconds of zero disable that rotation
+// trigger.
+func NewRotatingWriter(path string, maxSizeBytes, maxAgeSeconds int64) (*RotatingWriter, error) {
+	w := &RotatingWriter{path: path, maxSizeBytes: maxSizeBytes, maxAgeSeconds: maxAgeSeconds}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// WriteLine appends line followed by a newline, rotating first if the
+// configured size or age limit has been reached
+func (w *RotatingWriter) WriteLine(line []byte) (int, error) {
+	if w.shouldRotate(len(line)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(append(line, '\n'))
+	w.size += int64(n)
+	if err != nil {
+		return n, fmt.Errorf("writing log line to %s: %w", w.path, err)
+	}
+	return n, nil
+}
+
+// shouldRotate reports whether writing an additional line would exceed the
+// configured size limit, or whether the file has been open past the
+// configured age limit
+func (w *RotatingWriter) shouldRotate(lineLen int) bool {
+	if w.maxSizeBytes > 0 && w.size+int64(lineLen)+1 > w.maxSizeBytes {
+		return true
+	}
+	if w.maxAgeSeconds > 0 && time.Since(w.openedAt) > time.Duration(w.maxAgeSeconds)*time.Second {
+		return true
+	}
+	return false
+}
+
+// rotate closes the current file, renames it to a .1 backup, and reopens path
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("closing log file for rotation: %w", err)
+	}
+
+	backup := w.path + ".1"
+	if err := os.Rename(w.path, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotating log file %s: %w", w.path, err)
+	}
+
+	return w.open()
+}
+
+// open creates the parent directory if needed and opens/reopens path for appending
+func (w *RotatingWriter) open() error {
+	dir := filepath.Dir(w.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening log file %s: %w", w.path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("statting log file %s: %w", w.path, err)
+	}
+
+	w.file = f
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
+
+// Close closes the underlying file
+func (w *RotatingWriter) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,56 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/file"
+)
+
+func TestRotatingWriter_WriteLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pod", "container.log")
+
+	w, err := file.NewRotatingWriter(path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error: %v", err)
+	}
+	defer w.Close()
+
+	if _, err := w.WriteLine([]byte("line one")); err != nil {
+		t.Fatalf("WriteLine() error: %v", err)
+	}
+	if _, err := w.WriteLine([]byte("line two")); err != nil {
+		t.Fatalf("WriteLine() error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading log file: %v", err)
+	}
+
+	want := "line one\nline two\n"
+	if string(content) != want {
+		t.Errorf("log file content = %q, want %q", content, want)
+	}
+}
+
+func TestRotatingWriter_RotatesOnSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "container.log")
+
+	w, err := file.NewRotatingWriter(path, 10, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error: %v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := w.WriteLine([]byte("0123456789")); err != nil {
+			t.Fatalf("WriteLine() error: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("expected rotated backup file to exist: %v", err)
+	}
+}
@@ -2,44 +2,184 @@ package http
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
 	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+	"github.com/wasim-nihal/k8s-watcher/pkg/metrics"
 )
 
+var tlsMinVersions = map[string]uint16{
+	config.TLSMinVersion12: tls.VersionTLS12,
+	config.TLSMinVersion13: tls.VersionTLS13,
+}
+
 // Client handles HTTP requests with retry logic
 type Client struct {
-	client *http.Client
-	config config.RequestConfig
+	client    *http.Client
+	config    config.RequestConfig
+	certStore *tlsCertificateStore // nil unless config.TLS.CertFile is set
+
+	batchMu     sync.Mutex
+	batchBuffer []interface{}
+	batchTimer  *time.Timer
+
+	// oauth2Source fetches and caches client-credentials access tokens; nil
+	// unless config.Auth.OAuth2.TokenURL is set.
+	oauth2Source oauth2.TokenSource
+}
+
+// BatchEnvelope is the wrapper object a batch of notifications is flushed
+// as, modeled on the git-lfs Batch API
+type BatchEnvelope struct {
+	Operation string        `json:"operation"`
+	Objects   []interface{} `json:"objects"`
 }
 
 // NewClient creates a new HTTP client with the given configuration
-func NewClient(config config.RequestConfig) *Client {
+func NewClient(cfg config.RequestConfig) *Client {
+	tlsConfig, certStore, err := buildTLSConfig(cfg)
+	if err != nil {
+		// Already validated by Loader.validateRequest; this should not happen.
+		logger.Error("Failed to build TLS configuration for HTTP client", "error", err)
+		tlsConfig = &tls.Config{InsecureSkipVerify: cfg.SkipTLSVerify}
+	}
+
 	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: config.SkipTLSVerify,
-		},
+		TLSClientConfig: tlsConfig,
 	}
 
 	client := &http.Client{
-		Timeout:   time.Duration(config.Timeout) * time.Second,
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
 		Transport: transport,
 	}
 
-	return &Client{
-		client: client,
-		config: config,
+	c := &Client{
+		client:    client,
+		config:    cfg,
+		certStore: certStore,
+	}
+
+	if cfg.Auth.OAuth2.TokenURL != "" {
+		ccCfg := &clientcredentials.Config{
+			ClientID:     cfg.Auth.OAuth2.ClientID,
+			ClientSecret: cfg.Auth.OAuth2.ClientSecret,
+			TokenURL:     cfg.Auth.OAuth2.TokenURL,
+			Scopes:       cfg.Auth.OAuth2.Scopes,
+		}
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+		c.oauth2Source = ccCfg.TokenSource(ctx)
 	}
+
+	return c
 }
 
-// SendNotification sends an HTTP request with retry logic
+// Reload re-reads the configured client certificate and key from disk, so a
+// rotated workload identity certificate (for example on SIGHUP) takes effect
+// without constructing a new Client.
+func (c *Client) Reload() error {
+	if c.certStore == nil {
+		return nil
+	}
+	return c.certStore.reload(c.config.TLS.CertFile, c.config.TLS.KeyFile)
+}
+
+// buildTLSConfig builds the tls.Config used for outbound requests, including
+// mutual TLS when cfg.TLS.CertFile is set. It returns a nil *tlsCertificateStore
+// when no client certificate is configured.
+func buildTLSConfig(cfg config.RequestConfig) (*tls.Config, *tlsCertificateStore, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.SkipTLSVerify,
+	}
+
+	if cfg.TLS.ServerName != "" {
+		tlsConfig.ServerName = cfg.TLS.ServerName
+	}
+
+	if version, ok := tlsMinVersions[cfg.TLS.MinVersion]; ok {
+		tlsConfig.MinVersion = version
+	}
+
+	if cfg.TLS.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.TLS.CAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading CA file %s: %w", cfg.TLS.CAFile, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, nil, fmt.Errorf("parsing CA file %s: no certificates found", cfg.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.TLS.CertFile == "" {
+		return tlsConfig, nil, nil
+	}
+
+	store := &tlsCertificateStore{}
+	if err := store.reload(cfg.TLS.CertFile, cfg.TLS.KeyFile); err != nil {
+		return nil, nil, err
+	}
+	tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return store.get()
+	}
+
+	return tlsConfig, store, nil
+}
+
+// tlsCertificateStore holds a client keypair that can be hot-reloaded
+// without rebuilding the surrounding http.Client or http.Transport
+type tlsCertificateStore struct {
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func (s *tlsCertificateStore) get() (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cert, nil
+}
+
+func (s *tlsCertificateStore) reload(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("loading client keypair: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cert = &cert
+	s.mu.Unlock()
+	return nil
+}
+
+// SendNotification sends an HTTP request with retry logic. When
+// config.Batch.Enabled is set, payload is buffered and flushed as part of a
+// batch instead of being sent immediately.
 func (c *Client) SendNotification(payload interface{}) error {
+	if c.config.Batch.Enabled {
+		return c.enqueueBatch(payload)
+	}
+
 	var body []byte
 	var err error
 
@@ -55,38 +195,197 @@ func (c *Client) SendNotification(payload interface{}) error {
 		method = "GET"
 	}
 
-	return c.doWithRetry(method, c.config.URL, body)
+	return c.doWithRetry(method, c.config.URL, body, false)
+}
+
+// enqueueBatch buffers payload for batched delivery, flushing immediately
+// once Batch.MaxSize is reached and otherwise arming a Batch.MaxWaitMs timer
+// so a slow trickle of events still gets delivered
+func (c *Client) enqueueBatch(payload interface{}) error {
+	c.batchMu.Lock()
+	c.batchBuffer = append(c.batchBuffer, payload)
+	flush := len(c.batchBuffer) >= c.batchMaxSize()
+	if !flush && c.batchTimer == nil {
+		c.batchTimer = time.AfterFunc(c.batchMaxWait(), func() {
+			if err := c.Flush(); err != nil {
+				logger.Error("Failed to flush batched webhook notifications", "error", err)
+			}
+		})
+	}
+	c.batchMu.Unlock()
+
+	if flush {
+		return c.Flush()
+	}
+	return nil
+}
+
+// Flush sends any buffered batch items as a single request immediately,
+// regardless of Batch.MaxSize or Batch.MaxWaitMs. It is a no-op if the
+// buffer is empty.
+func (c *Client) Flush() error {
+	c.batchMu.Lock()
+	if c.batchTimer != nil {
+		c.batchTimer.Stop()
+		c.batchTimer = nil
+	}
+	items := c.batchBuffer
+	c.batchBuffer = nil
+	c.batchMu.Unlock()
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(BatchEnvelope{Operation: "batch", Objects: items})
+	if err != nil {
+		return fmt.Errorf("marshaling batch payload: %w", err)
+	}
+
+	method := c.config.Method
+	if method == "" {
+		method = "POST"
+	}
+
+	// A batch delivery is always safe to retry: it's one request carrying
+	// many buffered items, not a single-item side effect, so the usual
+	// isIdempotentMethod check (which would otherwise exclude the default
+	// POST method) doesn't apply here.
+	return c.doWithRetry(method, c.config.URL, body, true)
+}
+
+// Shutdown flushes any buffered batch items if Batch.FlushOnShutdown is set,
+// bounded by ctx's deadline. It is a no-op when batching is disabled or
+// FlushOnShutdown is false.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if !c.config.Batch.Enabled || !c.config.Batch.FlushOnShutdown {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.Flush() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) batchMaxSize() int {
+	if c.config.Batch.MaxSize > 0 {
+		return c.config.Batch.MaxSize
+	}
+	return config.DefaultBatchMaxSize
 }
 
-// doWithRetry performs the HTTP request with retry logic
-func (c *Client) doWithRetry(method, url string, body []byte) error {
+func (c *Client) batchMaxWait() time.Duration {
+	if c.config.Batch.MaxWaitMs > 0 {
+		return time.Duration(c.config.Batch.MaxWaitMs) * time.Millisecond
+	}
+	return time.Duration(config.DefaultBatchMaxWaitMs) * time.Millisecond
+}
+
+// doWithRetry performs the HTTP request with retry logic, recording a
+// WebhookAttemptsTotal/WebhookDurationSeconds metrics observation for every
+// attempt (not just the final outcome). Retries use full-jitter exponential
+// backoff (sleep = rand(0, min(Cap, Base*2^attempt)), honoring a
+// Retry-After response header when present, and only fire for a retryable
+// status (retryConfig.RetryOn, defaulting to 429/5xx) on an idempotent
+// method, unless retryConfig.RetryNonIdempotent or forceRetryable is set.
+func (c *Client) doWithRetry(method, url string, body []byte, forceRetryable bool) error {
 	var lastErr error
 	retryConfig := c.config.Retry
+	retryable := forceRetryable || retryConfig.RetryNonIdempotent || isIdempotentMethod(method)
 
-	for attempt := 0; attempt <= retryConfig.Total; attempt++ {
-		if attempt > 0 {
-			backoffDuration := time.Duration(float64(attempt) * retryConfig.BackoffFactor * float64(time.Second))
-			time.Sleep(backoffDuration)
-		}
-
-		err := c.doRequest(method, url, body)
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		statusCode, retryAfter, err := c.doRequest(method, url, body)
+		metrics.RecordWebhookAttempt(url, metrics.StatusClass(statusCode), time.Since(start))
 		if err == nil {
 			return nil
 		}
-
 		lastErr = err
+
+		if attempt >= retryConfig.Total || !retryable || !isRetryableStatus(statusCode, retryConfig.RetryOn) {
+			break
+		}
+
+		delay := retryDelay(retryConfig, attempt+1, retryAfter)
 		logger.Warn("Request failed, retrying",
 			"attempt", attempt+1,
 			"maxAttempts", retryConfig.Total+1,
+			"delay", delay,
 			"error", err,
 		)
+		time.Sleep(delay)
 	}
 
 	return fmt.Errorf("all retry attempts failed: %w", lastErr)
 }
 
-// doRequest performs a single HTTP request
-func (c *Client) doRequest(method, url string, body []byte) error {
+// isIdempotentMethod reports whether method is safe to retry without risking
+// a duplicated side effect
+func isIdempotentMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case "", "GET", "HEAD", "OPTIONS", "PUT", "DELETE", "TRACE":
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableStatus reports whether statusCode should trigger a retry. A
+// statusCode of 0 (no response received, e.g. a connection error) is always
+// retryable.
+func isRetryableStatus(statusCode int, retryOn []int) bool {
+	if statusCode == 0 {
+		return true
+	}
+	if len(retryOn) > 0 {
+		for _, code := range retryOn {
+			if code == statusCode {
+				return true
+			}
+		}
+		return false
+	}
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// retryDelay computes the next backoff delay: retryAfter, if set by the
+// previous response's Retry-After header, otherwise
+// rand(0, min(cfg.Cap, cfg.Base*2^attempt))
+func retryDelay(cfg config.RetryConfig, attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	base := cfg.Base
+	if base <= 0 {
+		base = cfg.BackoffFactor
+	}
+	if base <= 0 {
+		base = config.DefaultRetryBase
+	}
+	capSeconds := cfg.Cap
+	if capSeconds <= 0 {
+		capSeconds = config.DefaultRetryCap
+	}
+
+	maxDelay := base * math.Pow(2, float64(attempt))
+	if maxDelay > capSeconds {
+		maxDelay = capSeconds
+	}
+
+	return time.Duration(rand.Float64() * maxDelay * float64(time.Second))
+}
+
+// doRequest performs a single HTTP request, returning the response status
+// code (0 if no response was received), the delay requested by a
+// Retry-After header (0 if absent), and an error for the caller to record
+func (c *Client) doRequest(method, url string, body []byte) (int, time.Duration, error) {
 	var req *http.Request
 	var err error
 
@@ -97,29 +396,30 @@ func (c *Client) doRequest(method, url string, body []byte) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return 0, 0, fmt.Errorf("creating request: %w", err)
 	}
 
 	if len(body) > 0 {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// Add basic auth if configured
-	if c.config.Auth.Basic.Username != "" {
-		req.SetBasicAuth(c.config.Auth.Basic.Username, c.config.Auth.Basic.Password)
+	if err := c.applyAuth(req, body); err != nil {
+		return 0, 0, fmt.Errorf("applying auth: %w", err)
 	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return 0, 0, fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+
 	// Read response body for error reporting
 	respBody, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return resp.StatusCode, retryAfter, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
 	}
 
 	logger.Info("Request completed successfully",
@@ -128,5 +428,55 @@ func (c *Client) doRequest(method, url string, body []byte) error {
 		"status", resp.StatusCode,
 	)
 
+	return resp.StatusCode, retryAfter, nil
+}
+
+// parseRetryAfter parses a Retry-After header (either delta-seconds or an
+// HTTP-date), returning 0 if value is empty or unparseable
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// applyAuth sets basic, bearer, OAuth2 and/or HMAC auth on req, in that
+// order of precedence for the Authorization header; HMAC signing is
+// independent and applies regardless of which (if any) of the others fired.
+func (c *Client) applyAuth(req *http.Request, body []byte) error {
+	switch {
+	case c.config.Auth.Basic.Username != "":
+		req.SetBasicAuth(c.config.Auth.Basic.Username, c.config.Auth.Basic.Password)
+	case c.config.Auth.Bearer.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.config.Auth.Bearer.Token)
+	case c.config.Auth.OAuth2.TokenURL != "":
+		token, err := c.oauth2Source.Token()
+		if err != nil {
+			return fmt.Errorf("fetching oauth2 token: %w", err)
+		}
+		token.SetAuthHeader(req)
+	}
+
+	if c.config.Auth.HMAC.Secret != "" {
+		header := c.config.Auth.HMAC.Header
+		if header == "" {
+			header = config.DefaultHMACHeader
+		}
+		mac := hmac.New(sha256.New, []byte(c.config.Auth.HMAC.Secret))
+		mac.Write(body)
+		req.Header.Set(header, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
 	return nil
 }
@@ -0,0 +1,768 @@
+package http_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	client "github.com/wasim-nihal/k8s-watcher/pkg/http"
+	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+)
+
+// testCA is a minimal self-signed CA used to issue both the test server's
+// certificate and the test client's certificate for mTLS round-trip tests.
+type testCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating CA key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating CA certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing CA certificate: %v", err)
+	}
+
+	return &testCA{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue creates a leaf certificate/key pair signed by the CA and writes both
+// as PEM files under t.TempDir(), returning their paths.
+func (ca *testCA) issue(t *testing.T, cn string, dnsNames []string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating leaf key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     dnsNames,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("creating leaf certificate: %v", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling leaf key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, cn+".crt")
+	keyFile = filepath.Join(dir, cn+".key")
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0644); err != nil {
+		t.Fatalf("writing cert file: %v", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatalf("writing key file: %v", err)
+	}
+
+	return certFile, keyFile
+}
+
+func (ca *testCA) writeCAFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(path, ca.certPEM, 0644); err != nil {
+		t.Fatalf("writing CA file: %v", err)
+	}
+	return path
+}
+
+type testPayload struct {
+	Message string `json:"message"`
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.RequestConfig
+	}{
+		{
+			name: "basic client",
+			config: config.RequestConfig{
+				Timeout:       10,
+				SkipTLSVerify: false,
+			},
+		},
+		{
+			name: "client with TLS skip verify",
+			config: config.RequestConfig{
+				Timeout:       5,
+				SkipTLSVerify: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := client.NewClient(tt.config)
+			if c == nil {
+				t.Error("NewClient() returned nil")
+			}
+		})
+	}
+}
+
+func TestSendNotification(t *testing.T) {
+	// Initialize logger
+	err := logger.Initialize(config.LoggingConfig{
+		Level:  "INFO",
+		Format: "JSON",
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	tests := []struct {
+		name         string
+		config       config.RequestConfig
+		payload      interface{}
+		serverFunc   func(http.ResponseWriter, *http.Request)
+		wantErr      bool
+		expectedBody string
+	}{
+		{
+			name: "successful GET request",
+			config: config.RequestConfig{
+				Method:  "GET",
+				Timeout: 5,
+			},
+			serverFunc: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "GET" {
+					t.Errorf("Expected GET request, got %s", r.Method)
+				}
+				w.WriteHeader(http.StatusOK)
+			},
+			wantErr: false,
+		},
+		{
+			name: "successful POST request with payload",
+			config: config.RequestConfig{
+				Method:  "POST",
+				Timeout: 5,
+			},
+			payload: testPayload{Message: "test message"},
+			serverFunc: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				var p testPayload
+				if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+					t.Errorf("Failed to decode request body: %v", err)
+				}
+				if p.Message != "test message" {
+					t.Errorf("Expected message 'test message', got %s", p.Message)
+				}
+				w.WriteHeader(http.StatusOK)
+			},
+			wantErr: false,
+		},
+		{
+			name: "server error with retry",
+			config: config.RequestConfig{
+				Method:  "GET",
+				Timeout: 5,
+				Retry: config.RetryConfig{
+					Total:         2,
+					BackoffFactor: 0.1,
+				},
+			},
+			serverFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverFunc))
+			defer server.Close()
+
+			tt.config.URL = server.URL
+			c := client.NewClient(tt.config)
+
+			err := c.SendNotification(tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SendNotification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryLogic(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 5,
+		Retry: config.RetryConfig{
+			Total:         3,
+			BackoffFactor: 0.1,
+		},
+	}
+
+	c := client.NewClient(cfg)
+	err := c.SendNotification(nil)
+	if err != nil {
+		t.Errorf("Expected success after retries, got error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Second)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 1, // 1 second timeout
+	}
+
+	c := client.NewClient(cfg)
+	err := c.SendNotification(nil)
+	if err == nil {
+		t.Error("Expected timeout error, got nil")
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	const (
+		username = "testuser"
+		password = "testpass"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != username || pass != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 5,
+		Auth: config.AuthConfig{
+			Basic: config.BasicAuth{
+				Username: username,
+				Password: password,
+			},
+		},
+	}
+
+	c := client.NewClient(cfg)
+	err := c.SendNotification(nil)
+	if err != nil {
+		t.Errorf("Expected successful authenticated request, got error: %v", err)
+	}
+}
+
+func TestBearerAuth(t *testing.T) {
+	const token = "test-bearer-token"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 5,
+		Auth: config.AuthConfig{
+			Bearer: config.BearerAuth{Token: token},
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(nil); err != nil {
+		t.Errorf("Expected successful authenticated request, got error: %v", err)
+	}
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parsing token request form: %v", err)
+		}
+		clientID, clientSecret, hasBasicAuth := r.BasicAuth()
+		if !hasBasicAuth {
+			clientID, clientSecret = r.FormValue("client_id"), r.FormValue("client_secret")
+		}
+		if r.FormValue("grant_type") != "client_credentials" || clientID != "id" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "fetched-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 5,
+		Auth: config.AuthConfig{
+			OAuth2: config.OAuth2Auth{
+				TokenURL:     tokenServer.URL,
+				ClientID:     "id",
+				ClientSecret: "secret",
+			},
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(nil); err != nil {
+		t.Errorf("Expected successful authenticated request, got error: %v", err)
+	}
+	if gotAuth != "Bearer fetched-token" {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, "Bearer fetched-token")
+	}
+}
+
+func TestHMACSignature(t *testing.T) {
+	const secret = "shhh"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		if r.Header.Get("X-Hub-Signature-256") != want {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "POST",
+		Timeout: 5,
+		Auth: config.AuthConfig{
+			HMAC: config.HMACAuth{Secret: secret, Header: "X-Hub-Signature-256"},
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(map[string]string{"hello": "world"}); err != nil {
+		t.Errorf("Expected successful signed request, got error: %v", err)
+	}
+}
+
+func TestRetryLogic_RetryOnHonored(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 5,
+		Retry: config.RetryConfig{
+			Total:   3,
+			Base:    0.01,
+			RetryOn: []int{500},
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(nil); err == nil {
+		t.Error("expected error for unconfigured retryOn status")
+	}
+	if attempts != 1 {
+		t.Errorf("expected exactly 1 attempt when status isn't in retryOn, got %d", attempts)
+	}
+}
+
+func TestRetryLogic_NonIdempotentNotRetried(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "POST",
+		Timeout: 5,
+		Retry: config.RetryConfig{
+			Total: 3,
+			Base:  0.01,
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(nil); err == nil {
+		t.Error("expected error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected exactly 1 attempt for a non-idempotent method, got %d", attempts)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	serverCertFile, serverKeyFile := ca.issue(t, "localhost", []string{"localhost", "127.0.0.1"})
+	clientCertFile, clientKeyFile := ca.issue(t, "test-client", nil)
+	caFile := ca.writeCAFile(t)
+
+	serverCert, err := tls.LoadX509KeyPair(serverCertFile, serverKeyFile)
+	if err != nil {
+		t.Fatalf("loading server keypair: %v", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 5,
+		TLS: config.TLSConfig{
+			CertFile:       clientCertFile,
+			KeyFile:        clientKeyFile,
+			CAFile:         caFile,
+			ServerName:     "localhost",
+			ClientAuthType: config.ClientAuthTypeRequireAndVerify,
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(nil); err != nil {
+		t.Errorf("SendNotification() with mutual TLS error = %v", err)
+	}
+}
+
+func TestMutualTLS_WrongCA(t *testing.T) {
+	ca := newTestCA(t)
+	serverCertFile, serverKeyFile := ca.issue(t, "localhost", []string{"localhost", "127.0.0.1"})
+	clientCertFile, clientKeyFile := ca.issue(t, "test-client", nil)
+
+	otherCA := newTestCA(t)
+	wrongCAFile := otherCA.writeCAFile(t)
+
+	serverCert, err := tls.LoadX509KeyPair(serverCertFile, serverKeyFile)
+	if err != nil {
+		t.Fatalf("loading server keypair: %v", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "GET",
+		Timeout: 5,
+		TLS: config.TLSConfig{
+			CertFile:   clientCertFile,
+			KeyFile:    clientKeyFile,
+			CAFile:     wrongCAFile,
+			ServerName: "localhost",
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(nil); err == nil {
+		t.Error("expected error when server certificate is not signed by the configured CA, got nil")
+	}
+}
+
+func TestClient_Reload(t *testing.T) {
+	ca := newTestCA(t)
+	clientCertFile, clientKeyFile := ca.issue(t, "test-client", nil)
+
+	cfg := config.RequestConfig{
+		Timeout: 5,
+		TLS: config.TLSConfig{
+			CertFile: clientCertFile,
+			KeyFile:  clientKeyFile,
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.Reload(); err != nil {
+		t.Errorf("Reload() error = %v", err)
+	}
+}
+
+func TestClient_Reload_NoClientCert(t *testing.T) {
+	c := client.NewClient(config.RequestConfig{Timeout: 5})
+	if err := c.Reload(); err != nil {
+		t.Errorf("Reload() with no client certificate configured should be a no-op, got error = %v", err)
+	}
+}
+
+func TestBatch_FlushesOnMaxSize(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	var lastBody batchRequestBody
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		json.NewDecoder(r.Body).Decode(&lastBody)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL: server.URL,
+		Batch: config.BatchConfig{
+			Enabled:   true,
+			MaxSize:   3,
+			MaxWaitMs: 60000,
+		},
+	}
+
+	c := client.NewClient(cfg)
+	for i := 0; i < 3; i++ {
+		if err := c.SendNotification(map[string]interface{}{"resource": i}); err != nil {
+			t.Fatalf("SendNotification() error = %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Errorf("expected 1 batched request after MaxSize items, got %d", requests)
+	}
+	if len(lastBody.Objects) != 3 {
+		t.Errorf("expected 3 objects in the batch, got %d", len(lastBody.Objects))
+	}
+	if lastBody.Operation != "batch" {
+		t.Errorf("expected operation 'batch', got %q", lastBody.Operation)
+	}
+}
+
+func TestBatch_FlushesOnMaxWait(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL: server.URL,
+		Batch: config.BatchConfig{
+			Enabled:   true,
+			MaxSize:   10,
+			MaxWaitMs: 20,
+		},
+	}
+
+	c := client.NewClient(cfg)
+	if err := c.SendNotification(map[string]interface{}{"resource": "one"}); err != nil {
+		t.Fatalf("SendNotification() error = %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Errorf("expected MaxWaitMs to trigger a partial flush, got %d requests", requests)
+	}
+}
+
+func TestBatch_RetriedThenDropped(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL: server.URL,
+		Batch: config.BatchConfig{
+			Enabled:   true,
+			MaxSize:   1,
+			MaxWaitMs: 60000,
+		},
+		Retry: config.RetryConfig{
+			Total:         2,
+			BackoffFactor: 0.1,
+		},
+	}
+
+	c := client.NewClient(cfg)
+	err := c.SendNotification(map[string]interface{}{"resource": "one"})
+	if err == nil {
+		t.Fatal("expected batch delivery to fail after exhausting retries")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 3 {
+		t.Errorf("expected 1 initial attempt + 2 retries = 3 requests, got %d", requests)
+	}
+}
+
+func TestClient_Flush_NoBufferedItems(t *testing.T) {
+	c := client.NewClient(config.RequestConfig{
+		Batch: config.BatchConfig{Enabled: true},
+	})
+	if err := c.Flush(); err != nil {
+		t.Errorf("Flush() with no buffered items should be a no-op, got error = %v", err)
+	}
+}
+
+type batchRequestBody struct {
+	Operation string                   `json:"operation"`
+	Objects   []map[string]interface{} `json:"objects"`
+}
+
+func BenchmarkSendNotification(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.RequestConfig{
+		URL:     server.URL,
+		Method:  "POST",
+		Timeout: 5,
+	}
+
+	c := client.NewClient(cfg)
+	payload := testPayload{Message: "benchmark test"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := c.SendNotification(payload)
+		if err != nil {
+			b.Fatalf("SendNotification failed: %v", err)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package label
 
 import (
+	"fmt"
+
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -29,35 +31,106 @@ func (m *Manager) MatchLabels(resourceLabels map[string]string) []config.LabelCo
 	return matches
 }
 
-// matchLabel checks if a single label configuration matches the resource labels
+// matchLabel checks if a single label configuration matches the resource
+// labels: Name/Value (if Name is set) must match, as well as every
+// MatchExpressions requirement, if any are configured. A config with no
+// Name is a pure set-based selector and is judged on MatchExpressions alone.
 func (m *Manager) matchLabel(cfg config.LabelConfig, resourceLabels map[string]string) bool {
-	if value, exists := resourceLabels[cfg.Name]; exists {
-		if cfg.Value == "" || cfg.Value == value {
-			return true
+	if cfg.Name != "" {
+		if value, exists := resourceLabels[cfg.Name]; !exists || (cfg.Value != "" && cfg.Value != value) {
+			return false
 		}
 	}
-	return false
+
+	if len(cfg.MatchExpressions) == 0 {
+		return true
+	}
+
+	selector, err := expressionsSelector(cfg.MatchExpressions)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(resourceLabels))
 }
 
-// GetSelector returns a label selector for all configured labels
+// GetSelector returns a labels.Selector suitable for API-server-side
+// filtering (e.g. metav1.ListOptions.LabelSelector), to pre-narrow the
+// watch stream before matchLabel's client-side filtering runs.
+//
+// A Kubernetes label selector can only express a single ANDed set of
+// requirements, so it cannot represent the OR across multiple independent
+// LabelConfig entries in the general case. With zero or one LabelConfig
+// configured, the returned selector is exact. With more than one,
+// narrowing it server-side would risk dropping resources that one of the
+// other configs should have matched, so GetSelector falls back to
+// labels.Everything() and every event is still checked by matchLabel.
 func (m *Manager) GetSelector() (labels.Selector, error) {
+	if len(m.configs) != 1 {
+		return labels.Everything(), nil
+	}
+	return configSelector(m.configs[0])
+}
+
+// configSelector builds the exact labels.Selector for a single LabelConfig:
+// its Name/Value requirement (if Name is set), ANDed with every configured
+// MatchExpressions requirement.
+func configSelector(cfg config.LabelConfig) (labels.Selector, error) {
 	selector := labels.NewSelector()
 
-	for _, cfg := range m.configs {
-		if cfg.Value == "" {
-			req, err := labels.NewRequirement(cfg.Name, selection.Exists, nil)
-			if err != nil {
-				return nil, err
-			}
-			selector = selector.Add(*req)
-		} else {
-			req, err := labels.NewRequirement(cfg.Name, selection.Equals, []string{cfg.Value})
-			if err != nil {
-				return nil, err
-			}
-			selector = selector.Add(*req)
+	if cfg.Name != "" {
+		op := selection.Exists
+		var values []string
+		if cfg.Value != "" {
+			op = selection.Equals
+			values = []string{cfg.Value}
+		}
+		req, err := labels.NewRequirement(cfg.Name, op, values)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*req)
+	}
+
+	for _, expr := range cfg.MatchExpressions {
+		req, err := expressionRequirement(expr)
+		if err != nil {
+			return nil, err
 		}
+		selector = selector.Add(*req)
 	}
 
 	return selector, nil
 }
+
+// expressionsSelector builds a labels.Selector from a set of set-based
+// requirements
+func expressionsSelector(exprs []config.LabelSelectorRequirement) (labels.Selector, error) {
+	selector := labels.NewSelector()
+	for _, expr := range exprs {
+		req, err := expressionRequirement(expr)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*req)
+	}
+	return selector, nil
+}
+
+// expressionRequirement converts a single config.LabelSelectorRequirement
+// into a labels.Requirement
+func expressionRequirement(expr config.LabelSelectorRequirement) (*labels.Requirement, error) {
+	var op selection.Operator
+	switch expr.Operator {
+	case config.LabelSelectorOpIn:
+		op = selection.In
+	case config.LabelSelectorOpNotIn:
+		op = selection.NotIn
+	case config.LabelSelectorOpExists:
+		op = selection.Exists
+	case config.LabelSelectorOpDoesNotExist:
+		op = selection.DoesNotExist
+	default:
+		return nil, fmt.Errorf("unknown matchExpressions operator %q", expr.Operator)
+	}
+	return labels.NewRequirement(expr.Key, op, expr.Values)
+}
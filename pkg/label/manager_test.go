@@ -48,6 +48,87 @@ func TestManager(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "matchExpressions In satisfied",
+			configs: []config.LabelConfig{
+				{
+					Name: "app",
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "env", Operator: config.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+					},
+				},
+			},
+			resourceLabels: map[string]string{
+				"app": "test",
+				"env": "prod",
+			},
+			want: []config.LabelConfig{
+				{
+					Name: "app",
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "env", Operator: config.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+					},
+				},
+			},
+		},
+		{
+			name: "matchExpressions NotIn excludes match",
+			configs: []config.LabelConfig{
+				{
+					Name: "app",
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "env", Operator: config.LabelSelectorOpNotIn, Values: []string{"prod"}},
+					},
+				},
+			},
+			resourceLabels: map[string]string{
+				"app": "test",
+				"env": "prod",
+			},
+			want: nil,
+		},
+		{
+			name: "matchExpressions DoesNotExist satisfied",
+			configs: []config.LabelConfig{
+				{
+					Name: "app",
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "deprecated", Operator: config.LabelSelectorOpDoesNotExist},
+					},
+				},
+			},
+			resourceLabels: map[string]string{
+				"app": "test",
+			},
+			want: []config.LabelConfig{
+				{
+					Name: "app",
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "deprecated", Operator: config.LabelSelectorOpDoesNotExist},
+					},
+				},
+			},
+		},
+		{
+			name: "matchExpressions-only config with no Name matches on selector alone",
+			configs: []config.LabelConfig{
+				{
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "env", Operator: config.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+					},
+				},
+			},
+			resourceLabels: map[string]string{
+				"env": "prod",
+			},
+			want: []config.LabelConfig{
+				{
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "env", Operator: config.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -70,35 +151,61 @@ func TestManager(t *testing.T) {
 	}
 }
 
-func TestGetSelector(t *testing.T) {
+func TestManager_GetSelector(t *testing.T) {
 	tests := []struct {
-		name      string
-		configs   []config.LabelConfig
-		wantError bool
+		name    string
+		configs []config.LabelConfig
+		want    string
 	}{
 		{
-			name: "single label",
+			name:    "no configs matches everything",
+			configs: nil,
+			want:    "",
+		},
+		{
+			name: "single name/value config is exact",
 			configs: []config.LabelConfig{
 				{Name: "app", Value: "test"},
 			},
-			wantError: false,
+			want: "app=test",
+		},
+		{
+			name: "single exists-only config is exact",
+			configs: []config.LabelConfig{
+				{Name: "app"},
+			},
+			want: "app",
 		},
 		{
-			name: "multiple labels",
+			name: "single matchExpressions-only config is exact",
+			configs: []config.LabelConfig{
+				{
+					MatchExpressions: []config.LabelSelectorRequirement{
+						{Key: "env", Operator: config.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+					},
+				},
+			},
+			want: "env in (prod,staging)",
+		},
+		{
+			name: "multiple configs fall back to everything, since OR isn't representable",
 			configs: []config.LabelConfig{
 				{Name: "app", Value: "test"},
-				{Name: "env", Value: "prod"},
+				{Name: "team", Value: "infra"},
 			},
-			wantError: false,
+			want: "",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			manager := label.NewManager(tt.configs)
-			_, err := manager.GetSelector()
-			if (err != nil) != tt.wantError {
-				t.Errorf("GetSelector() error = %v, wantError %v", err, tt.wantError)
+			selector, err := manager.GetSelector()
+			if err != nil {
+				t.Fatalf("GetSelector() error = %v", err)
+			}
+			if got := selector.String(); got != tt.want {
+				t.Errorf("GetSelector().String() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,118 @@
+// Package metrics exposes Prometheus collectors for watcher and webhook
+// activity, and a small HTTP server to scrape them (see server.go).
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// EventsTotal counts resource events observed by the watcher, before
+	// label matching is applied.
+	EventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_watcher_events_total",
+		Help: "Total number of resource events observed.",
+	}, []string{"resource_type", "namespace", "event"})
+
+	// FilesWrittenTotal counts output files successfully written to disk.
+	FilesWrittenTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_watcher_files_written_total",
+		Help: "Total number of output files written.",
+	}, []string{"resource_type"})
+
+	// FilesSkippedTotal counts resource events skipped because their
+	// resourceVersion was already processed.
+	FilesSkippedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_watcher_files_skipped_total",
+		Help: "Total number of resource events skipped as already processed.",
+	}, []string{"resource_type"})
+
+	// ScriptExecutionsTotal counts configured script executions by outcome.
+	ScriptExecutionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_watcher_script_executions_total",
+		Help: "Total number of configured scripts executed, labeled by outcome.",
+	}, []string{"status"})
+
+	// ScriptDurationSeconds observes how long configured scripts take to run.
+	ScriptDurationSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "k8s_watcher_script_duration_seconds",
+		Help:    "Duration of script executions in seconds.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// WebhookAttemptsTotal counts every webhook delivery attempt (including
+	// retries), labeled by destination URL and response status class.
+	WebhookAttemptsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8s_watcher_webhook_attempts_total",
+		Help: "Total number of webhook delivery attempts, labeled by URL and status class.",
+	}, []string{"url", "status"})
+
+	// WebhookDurationSeconds observes webhook request latency per attempt.
+	WebhookDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k8s_watcher_webhook_duration_seconds",
+		Help:    "Duration of webhook requests in seconds, labeled by URL.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"url"})
+
+	// QueueDepth reports the current length of the workqueue, sampled on
+	// every enqueue and dequeue.
+	QueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "k8s_watcher_queue_depth",
+		Help: "Current number of items waiting in the resource event workqueue.",
+	})
+)
+
+// Script execution outcomes for the ScriptExecutionsTotal status label
+const (
+	ScriptStatusSuccess = "success"
+	ScriptStatusTimeout = "timeout"
+	ScriptStatusError   = "error"
+)
+
+// RecordEvent increments EventsTotal for a single observed resource event
+func RecordEvent(resourceType, namespace, event string) {
+	EventsTotal.WithLabelValues(resourceType, namespace, event).Inc()
+}
+
+// RecordFileWritten increments FilesWrittenTotal for a successfully written output file
+func RecordFileWritten(resourceType string) {
+	FilesWrittenTotal.WithLabelValues(resourceType).Inc()
+}
+
+// RecordFileSkipped increments FilesSkippedTotal for a resource event
+// skipped because its resourceVersion was already processed
+func RecordFileSkipped(resourceType string) {
+	FilesSkippedTotal.WithLabelValues(resourceType).Inc()
+}
+
+// RecordScriptExecution records a script execution's outcome and duration
+func RecordScriptExecution(status string, duration time.Duration) {
+	ScriptExecutionsTotal.WithLabelValues(status).Inc()
+	ScriptDurationSeconds.Observe(duration.Seconds())
+}
+
+// RecordWebhookAttempt records a single webhook delivery attempt (one call
+// per retry, not just the final outcome) and its latency
+func RecordWebhookAttempt(url, status string, duration time.Duration) {
+	WebhookAttemptsTotal.WithLabelValues(url, status).Inc()
+	WebhookDurationSeconds.WithLabelValues(url).Observe(duration.Seconds())
+}
+
+// SetQueueDepth records the workqueue's current length
+func SetQueueDepth(depth int) {
+	QueueDepth.Set(float64(depth))
+}
+
+// StatusClass buckets an HTTP status code into a status class label
+// ("2xx", "4xx", ...). A non-positive statusCode (no response received,
+// e.g. a connection failure) is reported as "error".
+func StatusClass(statusCode int) string {
+	if statusCode <= 0 {
+		return "error"
+	}
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
@@ -0,0 +1,125 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/metrics"
+)
+
+func TestServer_Metrics_ScrapeContainsRecordedFamilies(t *testing.T) {
+	metrics.RecordEvent("configmap", "default", "Added")
+	metrics.RecordFileWritten("configmap")
+	metrics.RecordFileSkipped("configmap")
+	metrics.RecordScriptExecution(metrics.ScriptStatusSuccess, 10*time.Millisecond)
+	metrics.RecordWebhookAttempt("http://example.invalid/webhook", "2xx", 5*time.Millisecond)
+	metrics.SetQueueDepth(3)
+
+	srv := metrics.NewServer(config.MetricsConfig{})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + config.DefaultMetricsPath)
+	if err != nil {
+		t.Fatalf("GET %s: %v", config.DefaultMetricsPath, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %v", err)
+	}
+	text := string(data)
+
+	for _, want := range []string{
+		"k8s_watcher_events_total",
+		"k8s_watcher_files_written_total",
+		"k8s_watcher_files_skipped_total",
+		"k8s_watcher_script_executions_total",
+		"k8s_watcher_script_duration_seconds",
+		"k8s_watcher_webhook_attempts_total",
+		"k8s_watcher_webhook_duration_seconds",
+		"k8s_watcher_queue_depth",
+		`resource_type="configmap"`,
+		`status="2xx"`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected /metrics output to contain %q", want)
+		}
+	}
+}
+
+func TestServer_Healthz(t *testing.T) {
+	srv := metrics.NewServer(config.MetricsConfig{})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_Readyz(t *testing.T) {
+	srv := metrics.NewServer(config.MetricsConfig{})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/readyz")
+	if err != nil {
+		t.Fatalf("GET /readyz: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 before SetLeader, got %d", resp.StatusCode)
+	}
+
+	srv.SetLeader(false)
+	resp, err = http.Get(ts.URL + "/readyz")
+	if err != nil {
+		t.Fatalf("GET /readyz: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503 after SetLeader(false), got %d", resp.StatusCode)
+	}
+
+	srv.SetLeader(true)
+	resp, err = http.Get(ts.URL + "/readyz")
+	if err != nil {
+		t.Fatalf("GET /readyz: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 after SetLeader(true), got %d", resp.StatusCode)
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		code int
+		want string
+	}{
+		{200, "2xx"},
+		{201, "2xx"},
+		{404, "4xx"},
+		{500, "5xx"},
+		{0, "error"},
+	}
+
+	for _, tt := range tests {
+		if got := metrics.StatusClass(tt.code); got != tt.want {
+			t.Errorf("StatusClass(%d) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+)
+
+// grpcConn is the subset of *grpc.ClientConn used by grpcNotifier, narrowed
+// so tests can substitute an in-memory fake instead of a real server.
+type grpcConn interface {
+	Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error
+	Close() error
+}
+
+// grpcNotifier delivers events by invoking a configured gRPC method with a
+// generic google.protobuf.Struct payload, so it can forward to any service
+// without k8s-watcher vendoring that service's generated stubs.
+type grpcNotifier struct {
+	conn   grpcConn
+	method string
+}
+
+func newGRPCNotifier(cfg config.GRPCConfig) (*grpcNotifier, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS.CertFile != "" || cfg.TLS.CAFile != "" {
+		tlsConfig, err := buildTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("building grpc TLS configuration: %w", err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(cfg.Target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("dialing grpc target %s: %w", cfg.Target, err)
+	}
+
+	return &grpcNotifier{conn: conn, method: cfg.Method}, nil
+}
+
+func (n *grpcNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshaling event: %w", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fmt.Errorf("decoding event: %w", err)
+	}
+
+	payload, err := structpb.NewStruct(fields)
+	if err != nil {
+		return fmt.Errorf("converting event to protobuf struct: %w", err)
+	}
+
+	return n.conn.Invoke(ctx, n.method, payload, &structpb.Struct{})
+}
+
+func (n *grpcNotifier) Close() error {
+	return n.conn.Close()
+}
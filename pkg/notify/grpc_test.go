@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type fakeGRPCConn struct {
+	invokedMethod string
+	invokedArgs   interface{}
+	invokeErr     error
+	closed        bool
+}
+
+func (f *fakeGRPCConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	f.invokedMethod = method
+	f.invokedArgs = args
+	return f.invokeErr
+}
+
+func (f *fakeGRPCConn) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestGRPCNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name      string
+		invokeErr error
+		wantErr   bool
+	}{
+		{name: "successful invoke"},
+		{name: "unavailable", invokeErr: errors.New("rpc error: unavailable"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeGRPCConn{invokeErr: tt.invokeErr}
+			n := &grpcNotifier{conn: fake, method: "/pkg.Notifications/Notify"}
+
+			err := n.Notify(context.Background(), Event{Namespace: "default", Name: "my-configmap", Action: "Added"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if fake.invokedMethod != "/pkg.Notifications/Notify" {
+				t.Errorf("expected method %q, got %q", "/pkg.Notifications/Notify", fake.invokedMethod)
+			}
+			if _, ok := fake.invokedArgs.(*structpb.Struct); !ok {
+				t.Errorf("expected invoke args to be a *structpb.Struct, got %T", fake.invokedArgs)
+			}
+		})
+	}
+}
+
+func TestGRPCNotifier_Close(t *testing.T) {
+	fake := &fakeGRPCConn{}
+	n := &grpcNotifier{conn: fake, method: "/pkg.Notifications/Notify"}
+
+	if err := n.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if !fake.closed {
+		t.Error("expected the connection to be closed")
+	}
+}
@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/http"
+)
+
+// httpNotifier delivers events via pkg/http.Client, preserving its existing
+// retry, mutual TLS, and batching behavior
+type httpNotifier struct {
+	client *http.Client
+}
+
+func newHTTPNotifier(cfg config.RequestConfig) *httpNotifier {
+	return &httpNotifier{client: http.NewClient(cfg)}
+}
+
+func (n *httpNotifier) Notify(ctx context.Context, event Event) error {
+	return n.client.SendNotification(event.Payload)
+}
+
+func (n *httpNotifier) Close() error {
+	return n.client.Shutdown(context.Background())
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+)
+
+// kafkaWriter is the subset of *kafka.Writer used by kafkaNotifier, narrowed
+// so tests can substitute an in-memory fake instead of a real broker.
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// kafkaNotifier delivers events to a Kafka topic via github.com/segmentio/kafka-go
+type kafkaNotifier struct {
+	writer kafkaWriter
+}
+
+func newKafkaNotifier(cfg config.KafkaConfig) (*kafkaNotifier, error) {
+	transport := &kafka.Transport{}
+
+	if cfg.TLS.CertFile != "" || cfg.TLS.CAFile != "" {
+		tlsConfig, err := buildTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("building kafka TLS configuration: %w", err)
+		}
+		transport.TLS = tlsConfig
+	}
+
+	mechanism, err := kafkaSASLMechanism(cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+	transport.SASL = mechanism
+
+	writer := &kafka.Writer{
+		Addr:      kafka.TCP(cfg.Brokers...),
+		Topic:     cfg.Topic,
+		Balancer:  &kafka.LeastBytes{},
+		Transport: transport,
+	}
+
+	return &kafkaNotifier{writer: writer}, nil
+}
+
+// kafkaSASLMechanism builds the sasl.Mechanism for the configured
+// KafkaConfig.SASL, returning nil when no mechanism is configured
+func kafkaSASLMechanism(cfg config.SASLConfig) (sasl.Mechanism, error) {
+	switch cfg.Mechanism {
+	case "":
+		return nil, nil
+	case config.SASLMechanismPlain:
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case config.SASLMechanismScramSHA256:
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case config.SASLMechanismScramSHA512:
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	default:
+		return nil, fmt.Errorf("unsupported kafka SASL mechanism: %s", cfg.Mechanism)
+	}
+}
+
+func (n *kafkaNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshaling event: %w", err)
+	}
+
+	return n.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Namespace + "/" + event.Name),
+		Value: body,
+	})
+}
+
+func (n *kafkaNotifier) Close() error {
+	return n.writer.Close()
+}
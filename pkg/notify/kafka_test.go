@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type fakeKafkaWriter struct {
+	messages []kafka.Message
+	writeErr error
+	closed   bool
+}
+
+func (f *fakeKafkaWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	f.messages = append(f.messages, msgs...)
+	return nil
+}
+
+func (f *fakeKafkaWriter) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestKafkaNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name     string
+		writeErr error
+		wantErr  bool
+	}{
+		{name: "successful publish"},
+		{name: "broker error", writeErr: errors.New("broker unavailable"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeKafkaWriter{writeErr: tt.writeErr}
+			n := &kafkaNotifier{writer: fake}
+
+			err := n.Notify(context.Background(), Event{Namespace: "default", Name: "my-configmap", Action: "Added"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr {
+				if len(fake.messages) != 1 {
+					t.Fatalf("expected 1 published message, got %d", len(fake.messages))
+				}
+				if string(fake.messages[0].Key) != "default/my-configmap" {
+					t.Errorf("expected message key %q, got %q", "default/my-configmap", fake.messages[0].Key)
+				}
+			}
+		})
+	}
+}
+
+func TestKafkaNotifier_Close(t *testing.T) {
+	fake := &fakeKafkaWriter{}
+	n := &kafkaNotifier{writer: fake}
+
+	if err := n.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if !fake.closed {
+		t.Error("expected the writer to be closed")
+	}
+}
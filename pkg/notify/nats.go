@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+)
+
+// natsConn is the subset of *nats.Conn used by natsNotifier, narrowed so
+// tests can substitute an in-memory fake instead of a real server.
+type natsConn interface {
+	Publish(subject string, data []byte) error
+	Drain() error
+}
+
+// natsNotifier delivers events to a NATS subject via github.com/nats-io/nats.go
+type natsNotifier struct {
+	conn    natsConn
+	subject string
+}
+
+func newNATSNotifier(cfg config.NATSConfig) (*natsNotifier, error) {
+	var opts []nats.Option
+
+	if cfg.TLS.CertFile != "" || cfg.TLS.CAFile != "" {
+		tlsConfig, err := buildTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("building nats TLS configuration: %w", err)
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+
+	conn, err := nats.Connect(strings.Join(cfg.URLs, ","), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to nats: %w", err)
+	}
+
+	return &natsNotifier{conn: conn, subject: cfg.Subject}, nil
+}
+
+func (n *natsNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshaling event: %w", err)
+	}
+
+	return n.conn.Publish(n.subject, body)
+}
+
+func (n *natsNotifier) Close() error {
+	return n.conn.Drain()
+}
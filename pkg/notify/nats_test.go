@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeNATSConn struct {
+	published  [][]byte
+	publishErr error
+	drained    bool
+}
+
+func (f *fakeNATSConn) Publish(subject string, data []byte) error {
+	if f.publishErr != nil {
+		return f.publishErr
+	}
+	f.published = append(f.published, data)
+	return nil
+}
+
+func (f *fakeNATSConn) Drain() error {
+	f.drained = true
+	return nil
+}
+
+func TestNATSNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name       string
+		publishErr error
+		wantErr    bool
+	}{
+		{name: "successful publish"},
+		{name: "connection error", publishErr: errors.New("no servers available"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeNATSConn{publishErr: tt.publishErr}
+			n := &natsNotifier{conn: fake, subject: "k8s-watcher.events"}
+
+			err := n.Notify(context.Background(), Event{Namespace: "default", Name: "my-secret", Action: "Updated"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && len(fake.published) != 1 {
+				t.Fatalf("expected 1 published message, got %d", len(fake.published))
+			}
+		})
+	}
+}
+
+func TestNATSNotifier_Close(t *testing.T) {
+	fake := &fakeNATSConn{}
+	n := &natsNotifier{conn: fake, subject: "k8s-watcher.events"}
+
+	if err := n.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if !fake.drained {
+		t.Error("expected the connection to be drained")
+	}
+}
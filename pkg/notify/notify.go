@@ -0,0 +1,49 @@
+// Package notify dispatches resource events to a pluggable downstream sink,
+// so a watched ConfigMap/Secret/CustomResource change can be delivered over
+// HTTP, Kafka, NATS, or gRPC without pkg/watcher knowing which.
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+)
+
+// Event is a single resource notification dispatched through a Notifier
+type Event struct {
+	Namespace       string
+	Name            string
+	ResourceVersion string
+	Action          string
+	Payload         interface{}
+}
+
+// Notifier delivers resource events to a downstream sink. Implementations
+// must be safe for concurrent use.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+	Close() error
+}
+
+// New builds the Notifier for cfg.Transport, defaulting to config.TransportHTTP
+// when cfg.Transport is empty
+func New(cfg config.RequestConfig) (Notifier, error) {
+	transport := cfg.Transport
+	if transport == "" {
+		transport = config.TransportHTTP
+	}
+
+	switch transport {
+	case config.TransportHTTP:
+		return newHTTPNotifier(cfg), nil
+	case config.TransportKafka:
+		return newKafkaNotifier(cfg.Kafka)
+	case config.TransportNATS:
+		return newNATSNotifier(cfg.NATS)
+	case config.TransportGRPC:
+		return newGRPCNotifier(cfg.GRPC)
+	default:
+		return nil, fmt.Errorf("unknown request transport: %s", cfg.Transport)
+	}
+}
@@ -0,0 +1,82 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+	"github.com/wasim-nihal/k8s-watcher/pkg/notify"
+)
+
+func init() {
+	// Initialize logger for tests
+	err := logger.Initialize(config.LoggingConfig{
+		Level:  "INFO",
+		Format: "LOGFMT",
+	})
+	if err != nil {
+		panic("Failed to initialize logger for tests: " + err.Error())
+	}
+}
+
+func TestNew_HTTP(t *testing.T) {
+	var received map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n, err := notify.New(config.RequestConfig{URL: server.URL, Method: "POST", Timeout: 5})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer n.Close()
+
+	event := notify.Event{
+		Namespace:       "default",
+		Name:            "my-configmap",
+		ResourceVersion: "42",
+		Action:          "Added",
+		Payload:         map[string]interface{}{"resource": "my-configmap"},
+	}
+
+	if err := n.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if received["resource"] != "my-configmap" {
+		t.Errorf("expected payload to be delivered verbatim, got %v", received)
+	}
+}
+
+func TestNew_DefaultsToHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n, err := notify.New(config.RequestConfig{URL: server.URL, Timeout: 5})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer n.Close()
+
+	if err := n.Notify(context.Background(), notify.Event{}); err != nil {
+		t.Errorf("Notify() error = %v", err)
+	}
+}
+
+func TestNew_UnknownTransport(t *testing.T) {
+	_, err := notify.New(config.RequestConfig{Transport: "carrier-pigeon"})
+	if err == nil {
+		t.Error("expected an error for an unknown transport, got nil")
+	}
+}
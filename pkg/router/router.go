@@ -0,0 +1,83 @@
+// Package router resolves, for a single matched resource, which
+// Script/Request target should run. Modeled on the host+path handler maps
+// used by reverse proxies: the most specific config.RouteConfig matching the
+// resource's namespace, name and annotations wins, so a namespace+name match
+// beats a namespace-only match beats an annotation-only match beats a
+// wildcard catch-all (a Route with every selector left empty).
+package router
+
+import (
+	"path"
+	"strings"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+)
+
+// Target is the Script/Request pair resolved for a single resource
+type Target struct {
+	RouteName string
+	Script    config.ScriptConfig
+	Request   config.RequestConfig
+}
+
+// Router resolves the most specific config.RouteConfig matching a resource
+type Router struct {
+	routes []config.RouteConfig
+}
+
+// New creates a Router over a label's configured routes
+func New(routes []config.RouteConfig) *Router {
+	return &Router{routes: routes}
+}
+
+// Resolve returns the Target of the most specific Route matching namespace,
+// name and annotations. ok is false if no route matches at all. When two
+// routes tie on specificity (e.g. two annotation-only routes matching the
+// same resource), the first one listed in the config wins, since score <=
+// bestScore only replaces the current best on a strictly higher score.
+func (r *Router) Resolve(namespace, name string, annotations map[string]string) (target Target, ok bool) {
+	bestScore := -1
+
+	for _, route := range r.routes {
+		score, matched := specificity(route, namespace, name, annotations)
+		if !matched || score <= bestScore {
+			continue
+		}
+		bestScore = score
+		target = Target{RouteName: route.Name, Script: route.Script, Request: route.Request}
+		ok = true
+	}
+
+	return target, ok
+}
+
+// specificity scores how specifically route matches (higher wins), or
+// matched=false if route doesn't match at all. A Route with every selector
+// left empty matches everything as a catch-all, scoring 0.
+func specificity(route config.RouteConfig, namespace, name string, annotations map[string]string) (score int, matched bool) {
+	if route.Namespace != "" {
+		if route.Namespace != namespace {
+			return 0, false
+		}
+		score += 4
+	}
+
+	if route.NameGlob != "" {
+		glob, err := path.Match(route.NameGlob, name)
+		if err != nil || !glob {
+			return 0, false
+		}
+		score += 2
+	}
+
+	if route.Annotation != "" {
+		key, value, hasValue := strings.Cut(route.Annotation, "=")
+		actual, exists := annotations[key]
+		if !exists || (hasValue && actual != value) {
+			return 0, false
+		}
+		score++
+	}
+
+	return score, true
+}
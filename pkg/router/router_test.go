@@ -0,0 +1,79 @@
+package router_test
+
+import (
+	"testing"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/router"
+)
+
+func TestRouter_Resolve(t *testing.T) {
+	routes := []config.RouteConfig{
+		{Name: "catch-all"},
+		{Name: "team-a", Namespace: "team-a"},
+		{Name: "team-a-frontend", Namespace: "team-a", NameGlob: "frontend-*"},
+		{Name: "slack", Annotation: "notify.k8s-watcher/target=slack"},
+	}
+	r := router.New(routes)
+
+	tests := []struct {
+		name        string
+		namespace   string
+		resource    string
+		annotations map[string]string
+		wantRoute   string
+		wantOK      bool
+	}{
+		{name: "falls through to catch-all", namespace: "team-b", resource: "my-configmap", wantRoute: "catch-all", wantOK: true},
+		{name: "namespace match", namespace: "team-a", resource: "backend-api", wantRoute: "team-a", wantOK: true},
+		{name: "namespace+name glob beats namespace alone", namespace: "team-a", resource: "frontend-web", wantRoute: "team-a-frontend", wantOK: true},
+		{name: "annotation match", namespace: "team-b", resource: "my-secret", annotations: map[string]string{"notify.k8s-watcher/target": "slack"}, wantRoute: "slack", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, ok := r.Resolve(tt.namespace, tt.resource, tt.annotations)
+			if ok != tt.wantOK {
+				t.Fatalf("Resolve() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && target.RouteName != tt.wantRoute {
+				t.Errorf("Resolve() route = %q, want %q", target.RouteName, tt.wantRoute)
+			}
+		})
+	}
+}
+
+func TestRouter_Resolve_NoMatch(t *testing.T) {
+	r := router.New([]config.RouteConfig{
+		{Name: "team-a", Namespace: "team-a"},
+	})
+
+	if _, ok := r.Resolve("team-b", "my-configmap", nil); ok {
+		t.Error("expected no route to match")
+	}
+}
+
+func TestRouter_Resolve_EmptyRoutes(t *testing.T) {
+	r := router.New(nil)
+
+	if _, ok := r.Resolve("default", "my-configmap", nil); ok {
+		t.Error("expected no route to match an empty Router")
+	}
+}
+
+func TestRouter_Resolve_TiedSpecificity_FirstListedWins(t *testing.T) {
+	annotations := map[string]string{"a": "x", "b": "y"}
+
+	r := router.New([]config.RouteConfig{
+		{Name: "annotation-a", Annotation: "a=x"},
+		{Name: "annotation-b", Annotation: "b=y"},
+	})
+
+	target, ok := r.Resolve("default", "my-configmap", annotations)
+	if !ok {
+		t.Fatal("expected a route to match")
+	}
+	if target.RouteName != "annotation-a" {
+		t.Errorf("Resolve() route = %q, want %q (first listed of two equally-specific routes)", target.RouteName, "annotation-a")
+	}
+}
@@ -0,0 +1,145 @@
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"sigs.k8s.io/yaml"
+)
+
+// Supported template engines. These mirror config.TemplateEngineGoTemplate
+// and config.TemplateEngineSprig; duplicated here (rather than imported) so
+// this package has no dependency on pkg/config.
+const (
+	EngineGoTemplate = "gotemplate"
+	EngineSprig      = "sprig"
+)
+
+// ResourceMetadata exposes the subset of metav1.ObjectMeta available to
+// output templates
+type ResourceMetadata struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Data is the root object made available to an output template. Key/Value
+// hold the entry currently being rendered when a resource fans out into
+// multiple files via a filenameTemplate; Data holds every key of the resource.
+type Data struct {
+	Metadata      ResourceMetadata
+	Data          map[string]string
+	MatchedLabels map[string]string
+	Key           string
+	Value         string
+	// ResourceKind is the watched resource type (one of the
+	// config.ResourceType* constants: "configmap", "secret",
+	// "customresource"), letting one template tell resources of different
+	// kinds apart when a label matches more than one.
+	ResourceKind string
+}
+
+// Renderer renders resource data through a user-supplied Go template,
+// optionally extended with sprig functions
+type Renderer struct {
+	tmpl     *template.Template
+	filename *template.Template
+}
+
+// NewRenderer loads and parses the template at path. It returns (nil, nil)
+// when path is empty, since templated output is opt-in.
+func NewRenderer(path, engine, filenameTemplate string) (*Renderer, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	tmpl, err := ParseFile(path, engine)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Renderer{tmpl: tmpl}
+
+	if filenameTemplate != "" {
+		filenameTmpl, err := newTemplate("filename", engine).Parse(filenameTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("parsing filenameTemplate: %w", err)
+		}
+		r.filename = filenameTmpl
+	}
+
+	return r, nil
+}
+
+// ParseFile reads and parses the template file at path, without building a
+// Renderer. It is used both by NewRenderer and by pkg/config to validate
+// templates at config load time, so a bad template fails fast.
+func ParseFile(path, engine string) (*template.Template, error) {
+	switch engine {
+	case EngineGoTemplate, EngineSprig, "":
+		// Valid engine
+	default:
+		return nil, fmt.Errorf("invalid template engine: %s", engine)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading template %s: %w", path, err)
+	}
+
+	tmpl, err := newTemplate(path, engine).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
+
+// newTemplate builds a named template pre-loaded with toYaml, and with the
+// full sprig function map when engine is EngineSprig
+func newTemplate(name, engine string) *template.Template {
+	funcMap := template.FuncMap{"toYaml": toYaml}
+	if engine == EngineSprig {
+		for fnName, fn := range sprig.TxtFuncMap() {
+			funcMap[fnName] = fn
+		}
+	}
+
+	return template.New(name).Funcs(funcMap)
+}
+
+// Render executes the configured template against data
+func (r *Renderer) Render(data Data) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderFilename executes the configured filenameTemplate against data, or
+// returns data.Key unchanged when no filenameTemplate is configured
+func (r *Renderer) RenderFilename(data Data) (string, error) {
+	if r.filename == nil {
+		return data.Key, nil
+	}
+
+	var buf bytes.Buffer
+	if err := r.filename.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering filenameTemplate: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// toYaml renders v as YAML, for use as the {{ toYaml . }} template function
+func toYaml(v interface{}) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
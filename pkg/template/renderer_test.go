@@ -0,0 +1,102 @@
+package template_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/template"
+)
+
+func writeTemplateFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing template fixture: %v", err)
+	}
+	return path
+}
+
+func TestNewRenderer_NoPath(t *testing.T) {
+	r, err := template.NewRenderer("", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r != nil {
+		t.Error("expected nil renderer when no template path is configured")
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTemplateFile(t, dir, "output.tmpl", "name={{ .Metadata.Name }} key={{ .Key }} value={{ .Value }}")
+
+	r, err := template.NewRenderer(path, template.EngineGoTemplate, "")
+	if err != nil {
+		t.Fatalf("NewRenderer() error: %v", err)
+	}
+
+	out, err := r.Render(template.Data{
+		Metadata: template.ResourceMetadata{Name: "my-cm"},
+		Key:      "foo",
+		Value:    "bar",
+	})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+
+	want := "name=my-cm key=foo value=bar"
+	if string(out) != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+}
+
+func TestRenderer_Render_ResourceKind(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTemplateFile(t, dir, "output.tmpl", "kind={{ .ResourceKind }}")
+
+	r, err := template.NewRenderer(path, template.EngineGoTemplate, "")
+	if err != nil {
+		t.Fatalf("NewRenderer() error: %v", err)
+	}
+
+	out, err := r.Render(template.Data{ResourceKind: "secret"})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+
+	if string(out) != "kind=secret" {
+		t.Errorf("Render() = %q, want %q", out, "kind=secret")
+	}
+}
+
+func TestRenderer_RenderFilename(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTemplateFile(t, dir, "output.tmpl", "{{ .Value }}")
+
+	r, err := template.NewRenderer(path, template.EngineGoTemplate, "{{ .Metadata.Name }}-{{ .Key }}.conf")
+	if err != nil {
+		t.Fatalf("NewRenderer() error: %v", err)
+	}
+
+	name, err := r.RenderFilename(template.Data{
+		Metadata: template.ResourceMetadata{Name: "my-cm"},
+		Key:      "foo",
+	})
+	if err != nil {
+		t.Fatalf("RenderFilename() error: %v", err)
+	}
+
+	if name != "my-cm-foo.conf" {
+		t.Errorf("RenderFilename() = %q, want %q", name, "my-cm-foo.conf")
+	}
+}
+
+func TestParseFile_InvalidEngine(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTemplateFile(t, dir, "output.tmpl", "{{ .Value }}")
+
+	if _, err := template.ParseFile(path, "mustache"); err == nil {
+		t.Error("expected error for invalid template engine")
+	}
+}
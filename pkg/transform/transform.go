@@ -0,0 +1,106 @@
+// Package transform implements a Prometheus relabel_config-style pipeline,
+// evaluated against a resource's name/namespace/labels/annotations before
+// the file handler writes anything. Rules run in order; keep/drop can
+// short-circuit the event, while replace/hashmod/labelmap mutate the
+// object's annotations (read later by file.Handler.GetAnnotationPath and
+// anything else consuming annotations) so output routing can be changed
+// purely from config.
+package transform
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+)
+
+// Apply evaluates rules in order against obj, mutating its annotations for
+// replace/hashmod/labelmap actions. It returns false as soon as a drop rule
+// matches or a keep rule fails to match, signalling the caller to discard
+// the event without writing a file or running a label's script/webhook.
+func Apply(obj metav1.Object, rules []config.TransformConfig) bool {
+	for _, rule := range rules {
+		if !applyRule(obj, rule) {
+			return false
+		}
+	}
+	return true
+}
+
+func applyRule(obj metav1.Object, rule config.TransformConfig) bool {
+	re, err := regexp.Compile(rule.Regex)
+	if err != nil {
+		// Already validated at config load time; treat as a no-op match.
+		return true
+	}
+
+	switch rule.Action {
+	case config.TransformActionKeep:
+		return re.MatchString(sourceValue(obj, rule.SourceLabels))
+	case config.TransformActionDrop:
+		return !re.MatchString(sourceValue(obj, rule.SourceLabels))
+	case config.TransformActionReplace:
+		if rule.TargetLabel == "" {
+			return true
+		}
+		value := sourceValue(obj, rule.SourceLabels)
+		if !re.MatchString(value) {
+			return true
+		}
+		setAnnotation(obj, rule.TargetLabel, re.ReplaceAllString(value, rule.Replacement))
+	case config.TransformActionHashMod:
+		if rule.TargetLabel == "" || rule.Modulus == 0 {
+			return true
+		}
+		sum := fnv.New64a()
+		sum.Write([]byte(sourceValue(obj, rule.SourceLabels)))
+		setAnnotation(obj, rule.TargetLabel, strconv.FormatUint(sum.Sum64()%rule.Modulus, 10))
+	case config.TransformActionLabelMap:
+		for key, value := range obj.GetLabels() {
+			if re.MatchString(key) {
+				setAnnotation(obj, re.ReplaceAllString(key, rule.Replacement), value)
+			}
+		}
+	}
+
+	return true
+}
+
+// sourceValue joins the values named by sourceLabels with ";", mirroring
+// Prometheus relabeling. __name__ and __namespace__ are well-known names
+// resolving to the resource's name and namespace; anything else is looked
+// up in labels first, then annotations.
+func sourceValue(obj metav1.Object, sourceLabels []string) string {
+	values := make([]string, len(sourceLabels))
+	for i, name := range sourceLabels {
+		values[i] = lookupSource(obj, name)
+	}
+	return strings.Join(values, ";")
+}
+
+func lookupSource(obj metav1.Object, name string) string {
+	switch name {
+	case "__name__":
+		return obj.GetName()
+	case "__namespace__":
+		return obj.GetNamespace()
+	default:
+		if value, ok := obj.GetLabels()[name]; ok {
+			return value
+		}
+		return obj.GetAnnotations()[name]
+	}
+}
+
+func setAnnotation(obj metav1.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,107 @@
+package transform_test
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/transform"
+)
+
+func newObj(namespace, name string, labels, annotations map[string]string) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels, Annotations: annotations}
+}
+
+func TestApply_Keep(t *testing.T) {
+	rules := []config.TransformConfig{
+		{SourceLabels: []string{"env"}, Action: config.TransformActionKeep, Regex: "prod"},
+	}
+
+	if !transform.Apply(newObj("default", "cm", map[string]string{"env": "prod"}, nil), rules) {
+		t.Error("expected keep rule to match and retain the event")
+	}
+	if transform.Apply(newObj("default", "cm", map[string]string{"env": "staging"}, nil), rules) {
+		t.Error("expected keep rule to drop the event")
+	}
+}
+
+func TestApply_Drop(t *testing.T) {
+	rules := []config.TransformConfig{
+		{SourceLabels: []string{"env"}, Action: config.TransformActionDrop, Regex: "staging"},
+	}
+
+	if transform.Apply(newObj("default", "cm", map[string]string{"env": "staging"}, nil), rules) {
+		t.Error("expected drop rule to discard the event")
+	}
+	if !transform.Apply(newObj("default", "cm", map[string]string{"env": "prod"}, nil), rules) {
+		t.Error("expected drop rule to retain a non-matching event")
+	}
+}
+
+func TestApply_Replace(t *testing.T) {
+	rules := []config.TransformConfig{
+		{SourceLabels: []string{"__namespace__"}, Action: config.TransformActionReplace, Regex: "(.+)", TargetLabel: "k8s-sidecar-target-directory", Replacement: "/data/$1"},
+	}
+
+	obj := newObj("team-a", "cm", nil, nil)
+	if !transform.Apply(obj, rules) {
+		t.Fatal("replace rule should never drop the event")
+	}
+
+	if got := obj.Annotations["k8s-sidecar-target-directory"]; got != "/data/team-a" {
+		t.Errorf("annotation = %q, want %q", got, "/data/team-a")
+	}
+}
+
+func TestApply_HashMod(t *testing.T) {
+	rules := []config.TransformConfig{
+		{SourceLabels: []string{"__name__"}, Action: config.TransformActionHashMod, TargetLabel: "shard", Modulus: 4},
+	}
+
+	obj := newObj("default", "my-configmap", nil, nil)
+	if !transform.Apply(obj, rules) {
+		t.Fatal("hashmod rule should never drop the event")
+	}
+
+	shard, ok := obj.Annotations["shard"]
+	if !ok {
+		t.Fatal("expected shard annotation to be set")
+	}
+	if shard != "0" && shard != "1" && shard != "2" && shard != "3" {
+		t.Errorf("shard = %q, want a value in [0,4)", shard)
+	}
+}
+
+func TestApply_LabelMap(t *testing.T) {
+	rules := []config.TransformConfig{
+		{Action: config.TransformActionLabelMap, Regex: "team-(.+)", Replacement: "owner-$1"},
+	}
+
+	obj := newObj("default", "cm", map[string]string{"team-frontend": "true", "unrelated": "x"}, nil)
+	if !transform.Apply(obj, rules) {
+		t.Fatal("labelmap rule should never drop the event")
+	}
+
+	if got := obj.Annotations["owner-frontend"]; got != "true" {
+		t.Errorf("annotation = %q, want %q", got, "true")
+	}
+	if _, ok := obj.Annotations["owner-unrelated"]; ok {
+		t.Error("did not expect a non-matching label to be copied")
+	}
+}
+
+func TestApply_MultipleRulesShortCircuit(t *testing.T) {
+	rules := []config.TransformConfig{
+		{SourceLabels: []string{"env"}, Action: config.TransformActionKeep, Regex: "prod"},
+		{Action: config.TransformActionLabelMap, Regex: "(.+)", Replacement: "mirrored-$1"},
+	}
+
+	obj := newObj("default", "cm", map[string]string{"env": "staging"}, nil)
+	if transform.Apply(obj, rules) {
+		t.Fatal("expected the keep rule to drop the event before labelmap runs")
+	}
+	if len(obj.Annotations) != 0 {
+		t.Error("labelmap should not have run after the keep rule dropped the event")
+	}
+}
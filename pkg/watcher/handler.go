@@ -5,50 +5,169 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/yaml"
 
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
 	"github.com/wasim-nihal/k8s-watcher/pkg/file"
-	"github.com/wasim-nihal/k8s-watcher/pkg/http"
 	"github.com/wasim-nihal/k8s-watcher/pkg/label"
 	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+	"github.com/wasim-nihal/k8s-watcher/pkg/metrics"
+	"github.com/wasim-nihal/k8s-watcher/pkg/notify"
+	"github.com/wasim-nihal/k8s-watcher/pkg/router"
+	"github.com/wasim-nihal/k8s-watcher/pkg/template"
 )
 
-// ResourceHandler interface defines methods for handling resource events
+// Kubernetes Event reasons emitted on the watched object via eventRecorder
+const (
+	EventReasonProcessed     = "Processed"
+	EventReasonScriptFailed  = "ScriptFailed"
+	EventReasonWebhookFailed = "WebhookFailed"
+)
+
+// ResourceHandler interface defines methods for handling resource events.
+// Errors are returned (rather than only logged) so a workqueue-driven caller
+// can decide whether to retry the event.
 type ResourceHandler interface {
-	OnAdd(obj interface{})
-	OnUpdate(oldObj, newObj interface{})
-	OnDelete(obj interface{})
+	OnAdd(obj interface{}) error
+	OnUpdate(oldObj, newObj interface{}) error
+	OnDelete(obj interface{}) error
 }
 
 // Watcher implements the main watcher functionality
 type Watcher struct {
-	client            kubernetes.Interface
-	config            *config.Config
-	labelManager      *label.Manager
-	fileHandler       *file.Handler
-	informer          *ResourceInformer
-	processedVersions map[string]string // tracks resourceVersion of processed resources
+	client              kubernetes.Interface
+	dynamicClient       dynamic.Interface
+	config              *config.Config
+	labelManager        *label.Manager
+	fileHandler         *file.Handler
+	templateRenderer    *template.Renderer // nil unless cfg.Output.Template.Path is set
+	podLogStreamer      *podLogStreamer    // nil unless cfg.Resources.Type is config.ResourceTypePodLogs
+	informer            *ResourceInformer
+	processedVersionsMu sync.RWMutex
+	processedVersions   map[string]string         // tracks resourceVersion of processed resources, guarded by processedVersionsMu since workqueue workers call isProcessed/markProcessed concurrently
+	routers             map[string]*router.Router // per-label Router, only set for labels with Routes configured
+
+	notifiersMu sync.Mutex
+	notifiers   map[string]notify.Notifier // one persistent Notifier per label name (or label+route name), so Batch-mode buffering survives across events
+
+	listKeepMu sync.Mutex
+	// listKeep tracks, per namespace, every output path (re)written during
+	// the watcher's current LIST pass, so SyncListedNamespaces can garbage
+	// collect files whose source object no longer exists. Only populated
+	// when Resources.Method is config.WatchMethodList.
+	listKeep map[string]map[string]struct{}
+
+	// eventRecorder emits Normal/Warning Kubernetes Events on the watched
+	// object itself, so `kubectl describe` shows what k8s-watcher did with
+	// it alongside the k8s_watcher_events_total metric.
+	eventRecorder record.EventRecorder
+
+	// leading is 1 when this replica should act on resource events (file
+	// writes, scripts, webhooks), 0 when it should only keep its informer
+	// caches warm. Defaults to 1 so behavior is unchanged when leader
+	// election is disabled; callers running with leader election flip it
+	// via SetLeading as leadership is gained/lost, so standby replicas
+	// already have a hot cache and can take over with minimal latency.
+	leading int32
 }
 
-// NewWatcher creates a new watcher instance
-func NewWatcher(client kubernetes.Interface, cfg *config.Config) *Watcher {
+// NewWatcher creates a new watcher instance. dynamicClient may be nil unless
+// cfg.Resources.Type is config.ResourceTypeCustomResource.
+func NewWatcher(client kubernetes.Interface, dynamicClient dynamic.Interface, cfg *config.Config) *Watcher {
+	renderer, err := template.NewRenderer(cfg.Output.Template.Path, cfg.Output.Template.Engine, cfg.Output.Template.FilenameTemplate)
+	if err != nil {
+		// Already validated at config load time; this should not happen.
+		logger.Error("Failed to build output template renderer", "error", err)
+	}
+
+	labelManager := label.NewManager(cfg.Resources.Labels)
+	fileHandler := file.NewHandler(cfg.Output)
+
+	var podLogs *podLogStreamer
+	if cfg.Resources.Type == config.ResourceTypePodLogs {
+		podLogs = newPodLogStreamer(client, labelManager, fileHandler, cfg.Resources.PodLogs, cfg.Resources.WatchConfig)
+	}
+
+	routers := make(map[string]*router.Router)
+	for _, lbl := range cfg.Resources.Labels {
+		if len(lbl.Routes) > 0 {
+			routers[lbl.Name] = router.New(lbl.Routes)
+		}
+	}
+
 	w := &Watcher{
 		client:            client,
+		dynamicClient:     dynamicClient,
 		config:            cfg,
-		labelManager:      label.NewManager(cfg.Resources.Labels),
-		fileHandler:       file.NewHandler(cfg.Output),
+		labelManager:      labelManager,
+		fileHandler:       fileHandler,
+		templateRenderer:  renderer,
+		podLogStreamer:    podLogs,
 		processedVersions: make(map[string]string),
+		routers:           routers,
+		notifiers:         make(map[string]notify.Notifier),
+		listKeep:          make(map[string]map[string]struct{}),
+		leading:           1,
+		eventRecorder:     newEventRecorder(client),
 	}
 
-	w.informer = NewResourceInformer(client, cfg.Kubernetes.Namespace, &cfg.Resources, w)
+	w.informer = NewResourceInformer(client, dynamicClient, cfg.Kubernetes.Namespace, &cfg.Resources, w)
 	return w
 }
 
+// newEventRecorder builds an EventRecorder that publishes events through
+// client's CoreV1 Events API, component-tagged as "k8s-watcher"
+func newEventRecorder(client kubernetes.Interface) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "k8s-watcher"})
+}
+
+// recordResourceEvent emits a Kubernetes Event on obj, if obj is a
+// runtime.Object the API server can be made aware of (it always is for the
+// resource types handleResource supports). Errors resolving an object
+// reference are logged by the underlying recorder rather than returned,
+// matching how the rest of this package treats observability as best-effort.
+func (w *Watcher) recordResourceEvent(obj interface{}, eventType, reason, message string) {
+	if robj, ok := obj.(runtime.Object); ok {
+		w.eventRecorder.Event(robj, eventType, reason, message)
+	}
+}
+
+// SetLeading controls whether this replica acts on resource events. It is
+// safe to call concurrently with Start/OnAdd/OnUpdate/OnDelete, letting
+// leader-election callbacks flip it as leadership changes while the
+// informer keeps running underneath.
+func (w *Watcher) SetLeading(leading bool) {
+	var v int32
+	if leading {
+		v = 1
+	}
+	atomic.StoreInt32(&w.leading, v)
+}
+
+// IsLeading reports whether this replica is currently acting on resource
+// events (true when leader election is disabled, or this replica holds the
+// lease).
+func (w *Watcher) IsLeading() bool {
+	return atomic.LoadInt32(&w.leading) != 0
+}
+
 // Start begins watching resources
 func (w *Watcher) Start(ctx context.Context) error {
 	logger.Info("Starting watcher",
@@ -59,19 +178,123 @@ func (w *Watcher) Start(ctx context.Context) error {
 	return w.informer.Start(ctx)
 }
 
+// Shutdown closes every configured Notifier (flushing any buffered batch
+// notifications along the way), bounded by ctx's deadline. It should be
+// called after Start returns so buffered events aren't lost when the
+// process exits.
+func (w *Watcher) Shutdown(ctx context.Context) error {
+	w.notifiersMu.Lock()
+	notifiers := make([]notify.Notifier, 0, len(w.notifiers))
+	for _, n := range w.notifiers {
+		notifiers = append(notifiers, n)
+	}
+	w.notifiersMu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		var firstErr error
+		for _, n := range notifiers {
+			if err := n.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		done <- firstErr
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rememberListedPath records that filePath was (re)written for namespace
+// during the current LIST pass, so SyncListedNamespaces knows to keep it.
+func (w *Watcher) rememberListedPath(namespace, filePath string) {
+	w.listKeepMu.Lock()
+	defer w.listKeepMu.Unlock()
+
+	if w.listKeep[namespace] == nil {
+		w.listKeep[namespace] = make(map[string]struct{})
+	}
+	w.listKeep[namespace][filePath] = struct{}{}
+}
+
+// SyncListedNamespaces garbage-collects, for every namespace that had at
+// least one resource processed during the watcher's most recent LIST pass,
+// output files whose source object was not (re)written this pass. It is a
+// no-op when Resources.Method is not config.WatchMethodList. Called by
+// ResourceInformer once a full listing across all watched namespaces
+// completes.
+func (w *Watcher) SyncListedNamespaces() error {
+	if w.config.Resources.Method != config.WatchMethodList {
+		return nil
+	}
+
+	w.listKeepMu.Lock()
+	listKeep := w.listKeep
+	w.listKeep = make(map[string]map[string]struct{})
+	w.listKeepMu.Unlock()
+
+	for namespace, keep := range listKeep {
+		if err := w.fileHandler.SyncDir(namespace, keep); err != nil {
+			return fmt.Errorf("syncing namespace %s: %w", namespace, err)
+		}
+	}
+
+	return nil
+}
+
+// notifier returns the persistent Notifier for key (a label name, or a
+// label+route name when Routes are configured), creating it on first use
+// from reqCfg
+func (w *Watcher) notifier(key string, reqCfg config.RequestConfig) (notify.Notifier, error) {
+	w.notifiersMu.Lock()
+	defer w.notifiersMu.Unlock()
+
+	n, ok := w.notifiers[key]
+	if ok {
+		return n, nil
+	}
+
+	n, err := notify.New(reqCfg)
+	if err != nil {
+		return nil, err
+	}
+	w.notifiers[key] = n
+	return n, nil
+}
+
 // OnAdd handles resource addition events
-func (w *Watcher) OnAdd(obj interface{}) {
-	w.handleResource("Added", obj)
+func (w *Watcher) OnAdd(obj interface{}) error {
+	if w.podLogStreamer != nil {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			return w.podLogStreamer.handlePodAdd(pod)
+		}
+	}
+	return w.handleResource("Added", obj)
 }
 
-// OnUpdate handles resource update events
-func (w *Watcher) OnUpdate(oldObj, newObj interface{}) {
-	w.handleResource("Updated", newObj)
+// OnUpdate handles resource update events. Pod spec changes don't affect an
+// already-running log stream, so pod-logs updates are a no-op.
+func (w *Watcher) OnUpdate(oldObj, newObj interface{}) error {
+	if w.podLogStreamer != nil {
+		if _, ok := newObj.(*corev1.Pod); ok {
+			return nil
+		}
+	}
+	return w.handleResource("Updated", newObj)
 }
 
 // OnDelete handles resource deletion events
-func (w *Watcher) OnDelete(obj interface{}) {
-	w.handleResource("Deleted", obj)
+func (w *Watcher) OnDelete(obj interface{}) error {
+	if w.podLogStreamer != nil {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			return w.podLogStreamer.handlePodDelete(pod)
+		}
+	}
+	return w.handleResource("Deleted", obj)
 }
 
 // getResourceKey generates a unique key for a resource
@@ -79,29 +302,39 @@ func (w *Watcher) getResourceKey(metadata *metav1.ObjectMeta) string {
 	return fmt.Sprintf("%s/%s/%s", metadata.Namespace, metadata.Name, metadata.ResourceVersion)
 }
 
-// isProcessed checks if a resource version has already been processed
+// isProcessed checks if a resource version has already been processed. Safe
+// for concurrent use by the workqueue's worker pool.
 func (w *Watcher) isProcessed(metadata *metav1.ObjectMeta) bool {
 	if !w.config.Resources.WatchConfig.IgnoreProcessed {
 		return false
 	}
 
 	key := fmt.Sprintf("%s/%s", metadata.Namespace, metadata.Name)
+	w.processedVersionsMu.RLock()
 	lastVersion, exists := w.processedVersions[key]
+	w.processedVersionsMu.RUnlock()
 	return exists && lastVersion == metadata.ResourceVersion
 }
 
-// markProcessed marks a resource version as processed
+// markProcessed marks a resource version as processed. Safe for concurrent
+// use by the workqueue's worker pool.
 func (w *Watcher) markProcessed(metadata *metav1.ObjectMeta) {
 	if !w.config.Resources.WatchConfig.IgnoreProcessed {
 		return
 	}
 
 	key := fmt.Sprintf("%s/%s", metadata.Namespace, metadata.Name)
+	w.processedVersionsMu.Lock()
 	w.processedVersions[key] = metadata.ResourceVersion
+	w.processedVersionsMu.Unlock()
 }
 
-// handleResource processes a resource event
-func (w *Watcher) handleResource(action string, obj interface{}) {
+// handleResource processes a resource event. It returns an error if any
+// matching configuration failed to process, so callers driving a workqueue
+// can requeue the event instead of silently dropping it.
+func (w *Watcher) handleResource(action string, obj interface{}) error {
+	resourceType := resourceTypeLabel(obj)
+
 	var metadata *metav1.ObjectMeta
 	var data map[string][]byte
 
@@ -118,25 +351,47 @@ func (w *Watcher) handleResource(action string, obj interface{}) {
 	case *corev1.Secret:
 		metadata = &v.ObjectMeta
 		data = v.Data
+	case *unstructured.Unstructured:
+		metadata = &metav1.ObjectMeta{
+			Name:            v.GetName(),
+			Namespace:       v.GetNamespace(),
+			Labels:          v.GetLabels(),
+			Annotations:     v.GetAnnotations(),
+			ResourceVersion: v.GetResourceVersion(),
+		}
+		projected, err := w.projectCustomResource(v)
+		if err != nil {
+			return fmt.Errorf("projecting custom resource %s: %w", v.GetName(), err)
+		}
+		data = projected
 	default:
-		logger.Error("Unknown resource type", "type", fmt.Sprintf("%T", obj))
-		return
+		return fmt.Errorf("unknown resource type: %T", obj)
+	}
+
+	metrics.RecordEvent(resourceType, metadata.Namespace, action)
+
+	// In warm standby (leader election enabled, lease held by another
+	// replica) we still let the informer populate its cache above so
+	// failover is fast, but don't act on the event ourselves.
+	if !w.IsLeading() {
+		return nil
 	}
 
 	// Check if we've already processed this version
 	if w.isProcessed(metadata) {
+		metrics.RecordFileSkipped(resourceType)
 		logger.Debug("Skipping already processed resource version",
 			"name", metadata.Name,
 			"namespace", metadata.Namespace,
 			"resourceVersion", metadata.ResourceVersion,
 		)
-		return
+		return nil
 	}
 
 	// Check if the resource matches our label selectors
 	matchingConfigs := w.labelManager.MatchLabels(metadata.Labels)
 	if len(matchingConfigs) == 0 {
-		return
+		return nil
 	}
 
 	logger.Info("Processing resource",
@@ -147,51 +402,112 @@ func (w *Watcher) handleResource(action string, obj interface{}) {
 	)
 
 	// Process the resource for each matching configuration
+	var firstErr error
 	for _, cfg := range matchingConfigs {
-		if err := w.processResource(metadata, data, cfg); err != nil {
+		if err := w.processResource(obj, metadata, data, cfg, action, resourceType); err != nil {
 			logger.Error("Failed to process resource",
 				"name", metadata.Name,
 				"namespace", metadata.Namespace,
 				"error", err,
 			)
+			if firstErr == nil {
+				firstErr = err
+			}
 		}
 	}
 
+	if firstErr != nil {
+		return fmt.Errorf("processing resource %s/%s: %w", metadata.Namespace, metadata.Name, firstErr)
+	}
+
 	// Mark the resource as processed after successful processing
 	w.markProcessed(metadata)
+	w.recordResourceEvent(obj, corev1.EventTypeNormal, EventReasonProcessed,
+		fmt.Sprintf("%s processed by k8s-watcher", action))
+	return nil
 }
 
 // processResource handles a single resource for a specific label configuration
-func (w *Watcher) processResource(metadata *metav1.ObjectMeta, data map[string][]byte, cfg config.LabelConfig) error {
+func (w *Watcher) processResource(obj interface{}, metadata *metav1.ObjectMeta, data map[string][]byte, cfg config.LabelConfig, action, resourceType string) error {
 	// Write files
 	basePath := w.fileHandler.GetAnnotationPath(metadata.Annotations)
 	for key, content := range data {
-		filePath := w.fileHandler.GetOutputPath(metadata.Name, metadata.Namespace, key)
+		filename := key
+		if w.templateRenderer != nil {
+			rendered, renderedName, err := w.renderTemplate(metadata, data, cfg, key, content, resourceType)
+			if err != nil {
+				return fmt.Errorf("rendering template for key %s: %w", key, err)
+			}
+			content = rendered
+			filename = renderedName
+		}
+
+		filePath := w.fileHandler.GetOutputPath(metadata.Name, metadata.Namespace, filename)
 		// Use basePath if it's different from the default
 		if basePath != w.fileHandler.GetDefaultPath() {
-			filePath = filepath.Join(basePath, key)
+			filePath = filepath.Join(basePath, filename)
 		}
-		if err := w.fileHandler.WriteFile(filePath, content); err != nil {
+		wrote, err := w.fileHandler.WriteFileIfNewer(filePath, content, metadata.ResourceVersion)
+		if err != nil {
 			return fmt.Errorf("writing file: %w", err)
 		}
+		if wrote {
+			metrics.RecordFileWritten(resourceType)
+		} else {
+			metrics.RecordFileSkipped(resourceType)
+		}
+
+		if w.config.Resources.Method == config.WatchMethodList {
+			w.rememberListedPath(metadata.Namespace, filePath)
+		}
+	}
+
+	// Resolve the Script/Request target to run: the most specific configured
+	// Route for this resource, or cfg.Script/cfg.Request when the label has
+	// no Routes configured
+	notifierKey := cfg.Name
+	scriptCfg := cfg.Script
+	requestCfg := cfg.Request
+	if rt, ok := w.routers[cfg.Name]; ok {
+		target, matched := rt.Resolve(metadata.Namespace, metadata.Name, metadata.Annotations)
+		if !matched {
+			return nil
+		}
+		notifierKey = cfg.Name + "/" + target.RouteName
+		scriptCfg = target.Script
+		requestCfg = target.Request
 	}
 
 	// Execute script if configured
-	if cfg.Script.Path != "" {
-		if err := w.executeScript(cfg.Script); err != nil {
+	if scriptCfg.Path != "" {
+		if err := w.executeScript(scriptCfg); err != nil {
+			w.recordResourceEvent(obj, corev1.EventTypeWarning, EventReasonScriptFailed, err.Error())
 			return fmt.Errorf("executing script: %w", err)
 		}
 	}
 
 	// Send notification if configured
-	if cfg.Request.URL != "" {
-		client := http.NewClient(cfg.Request)
-		payload := map[string]interface{}{
-			"resource":  metadata.Name,
-			"namespace": metadata.Namespace,
-			"timestamp": time.Now().UTC(),
-		}
-		if err := client.SendNotification(payload); err != nil {
+	if requestCfg.URL != "" || requestCfg.Transport != "" {
+		n, err := w.notifier(notifierKey, requestCfg)
+		if err != nil {
+			return fmt.Errorf("creating notifier: %w", err)
+		}
+
+		event := notify.Event{
+			Namespace:       metadata.Namespace,
+			Name:            metadata.Name,
+			ResourceVersion: metadata.ResourceVersion,
+			Action:          action,
+			Payload: map[string]interface{}{
+				"resource":        metadata.Name,
+				"namespace":       metadata.Namespace,
+				"resourceVersion": metadata.ResourceVersion,
+				"action":          action,
+				"timestamp":       time.Now().UTC(),
+			},
+		}
+		if err := n.Notify(context.Background(), event); err != nil {
+			w.recordResourceEvent(obj, corev1.EventTypeWarning, EventReasonWebhookFailed, err.Error())
 			return fmt.Errorf("sending notification: %w", err)
 		}
 	}
@@ -199,7 +515,164 @@ func (w *Watcher) processResource(metadata *metav1.ObjectMeta, data map[string][
 	return nil
 }
 
-// executeScript runs the configured script
+// renderTemplate builds the template.Data scope for a single key of a
+// resource (.Metadata, .Data, .MatchedLabels, .Key, .Value, .ResourceKind)
+// and renders it, along with the filenameTemplate, so a single resource can
+// fan out into multiple templated files.
+func (w *Watcher) renderTemplate(metadata *metav1.ObjectMeta, data map[string][]byte, cfg config.LabelConfig, key string, content []byte, resourceType string) ([]byte, string, error) {
+	stringData := make(map[string]string, len(data))
+	for k, v := range data {
+		stringData[k] = string(v)
+	}
+
+	tmplData := template.Data{
+		Metadata: template.ResourceMetadata{
+			Name:        metadata.Name,
+			Namespace:   metadata.Namespace,
+			Labels:      metadata.Labels,
+			Annotations: metadata.Annotations,
+		},
+		Data:          stringData,
+		MatchedLabels: map[string]string{cfg.Name: cfg.Value},
+		Key:           key,
+		Value:         string(content),
+		ResourceKind:  resourceType,
+	}
+
+	rendered, err := w.templateRenderer.Render(tmplData)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename, err := w.templateRenderer.RenderFilename(tmplData)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return rendered, filename, nil
+}
+
+// customResourceFilename derives the output filename for a watched custom resource
+func customResourceFilename(u *unstructured.Unstructured) string {
+	kind := strings.ToLower(u.GetKind())
+	if kind == "" {
+		kind = "resource"
+	}
+	return kind + ".yaml"
+}
+
+// projectCustomResource turns an unstructured custom resource into the
+// map[string][]byte shape fileHandler.WriteFile expects. When the matching
+// GVRConfig.Fields is non-empty, each dotted path (e.g. "spec",
+// "data.config.yaml") is extracted into its own file named after the
+// resolved leaf key (see fieldFilename); otherwise the whole object is
+// marshaled to a single <kind>.yaml file, as before.
+func (w *Watcher) projectCustomResource(u *unstructured.Unstructured) (map[string][]byte, error) {
+	fields := w.customResourceFields(u.GroupVersionKind())
+	if len(fields) == 0 {
+		content, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return nil, fmt.Errorf("serializing custom resource %s: %w", u.GetName(), err)
+		}
+		return map[string][]byte{customResourceFilename(u): content}, nil
+	}
+
+	data := make(map[string][]byte, len(fields))
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		value, leafKey, found, err := nestedFieldDotted(u.Object, path)
+		if err != nil {
+			return nil, fmt.Errorf("reading field %q from custom resource %s: %w", field, u.GetName(), err)
+		}
+		if !found {
+			continue
+		}
+		content, err := yaml.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("serializing field %q of custom resource %s: %w", field, u.GetName(), err)
+		}
+		data[fieldFilename(leafKey)] = content
+	}
+	return data, nil
+}
+
+// fieldFilename derives the output filename for a resolved Fields leaf key.
+// A leaf key that already looks like a filename (e.g. a literal dotted key
+// such as "config.yaml") is used as-is; a bare key (e.g. "spec") gets a
+// ".yaml" extension appended, matching the plain single-segment case.
+func fieldFilename(leafKey string) string {
+	if strings.Contains(leafKey, ".") {
+		return leafKey
+	}
+	return leafKey + ".yaml"
+}
+
+// nestedFieldDotted looks up a "."-separated path in obj, the same way
+// unstructured.NestedFieldNoCopy does, except that a single map key is
+// allowed to contain literal dots. At each level it tries the longest
+// possible join of the remaining segments as one key before falling back to
+// a shorter join, so a CRD shaped like {"data": {"config.yaml": ...}} still
+// resolves the documented path "data.config.yaml" even though "config.yaml"
+// is one key, not two. Alongside the resolved value, it returns the literal
+// key that matched at the final level, so callers deriving an output
+// filename from the path don't have to re-split it and risk colliding two
+// different dotted keys that happen to share their last "."-segment.
+func nestedFieldDotted(obj map[string]interface{}, segments []string) (value interface{}, leafKey string, found bool, err error) {
+	var cur interface{} = obj
+	for len(segments) > 0 {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, "", false, fmt.Errorf("%v accessor error: %v is of the type %T, expected map[string]interface{}", segments, cur, cur)
+		}
+
+		matched := false
+		for i := len(segments); i >= 1; i-- {
+			key := strings.Join(segments[:i], ".")
+			if v, ok := m[key]; ok {
+				cur = v
+				leafKey = key
+				segments = segments[i:]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, "", false, nil
+		}
+	}
+	return cur, leafKey, true, nil
+}
+
+// customResourceFields looks up the Fields projection list configured for
+// the GVR matching gvk, returning nil when no entry matches or none was
+// configured.
+func (w *Watcher) customResourceFields(gvk schema.GroupVersionKind) []string {
+	for _, gvr := range w.config.Resources.CustomResource.GVRs() {
+		if gvr.Group == gvk.Group && gvr.Version == gvk.Version && gvr.Kind == gvk.Kind {
+			return gvr.Fields
+		}
+	}
+	return nil
+}
+
+// resourceTypeLabel derives the metrics resource_type label from the
+// informer's object type
+func resourceTypeLabel(obj interface{}) string {
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		return config.ResourceTypeConfigMap
+	case *corev1.Secret:
+		return config.ResourceTypeSecret
+	case *unstructured.Unstructured:
+		return config.ResourceTypeCustomResource
+	default:
+		return "unknown"
+	}
+}
+
+// executeScript runs the configured script, recording its outcome and
+// duration. A context deadline exceeded is recorded as a distinct "timeout"
+// status rather than a generic error.
 func (w *Watcher) executeScript(cfg config.ScriptConfig) error {
 	ctx := context.Background()
 	if cfg.Timeout > 0 {
@@ -208,12 +681,22 @@ func (w *Watcher) executeScript(cfg config.ScriptConfig) error {
 		defer cancel()
 	}
 
+	start := time.Now()
 	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", cfg.Path)
 	output, err := cmd.CombinedOutput()
+	duration := time.Since(start)
+
 	if err != nil {
+		status := metrics.ScriptStatusError
+		if ctx.Err() == context.DeadlineExceeded {
+			status = metrics.ScriptStatusTimeout
+		}
+		metrics.RecordScriptExecution(status, duration)
 		return fmt.Errorf("script execution failed: %w, output: %s", err, string(output))
 	}
 
+	metrics.RecordScriptExecution(metrics.ScriptStatusSuccess, duration)
+
 	logger.Info("Script executed successfully",
 		"path", cfg.Path,
 		"output", string(output),
@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
@@ -39,7 +41,7 @@ func TestNewWatcher(t *testing.T) {
 	}
 	client := fake.NewSimpleClientset()
 
-	w := NewWatcher(client, cfg)
+	w := NewWatcher(client, nil, cfg)
 	assert.NotNil(t, w)
 	assert.NotNil(t, w.labelManager)
 	assert.NotNil(t, w.fileHandler)
@@ -76,7 +78,7 @@ func TestWatcher_HandleResource(t *testing.T) {
 	}
 
 	client := fake.NewSimpleClientset()
-	w := NewWatcher(client, cfg)
+	w := NewWatcher(client, nil, cfg)
 
 	// Test ConfigMap handling
 	cm := &corev1.ConfigMap{
@@ -134,6 +136,57 @@ func TestWatcher_HandleResource(t *testing.T) {
 	assert.Equal(t, "secret content", string(content))
 }
 
+func TestWatcher_HandleResource_RejectsStaleResourceVersion(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-stale-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeConfigMap,
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+
+	cmPath := filepath.Join(tempDir, "default", "test-cm", "test.txt")
+
+	newer := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-cm",
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "test"},
+			ResourceVersion: "5",
+		},
+		Data: map[string]string{"test.txt": "newer content"},
+	}
+	require.NoError(t, w.OnAdd(newer))
+	content, err := os.ReadFile(cmPath)
+	require.NoError(t, err)
+	assert.Equal(t, "newer content", string(content))
+
+	stale := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-cm",
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "test"},
+			ResourceVersion: "2",
+		},
+		Data: map[string]string{"test.txt": "stale content"},
+	}
+	require.NoError(t, w.OnAdd(stale))
+	content, err = os.ReadFile(cmPath)
+	require.NoError(t, err)
+	assert.Equal(t, "newer content", string(content), "a stale resourceVersion must not overwrite a newer write")
+}
+
 func TestWatcher_ExecuteScript(t *testing.T) {
 	cfg := &config.Config{
 		Resources: config.ResourceConfig{
@@ -152,7 +205,7 @@ func TestWatcher_ExecuteScript(t *testing.T) {
 	}
 
 	client := fake.NewSimpleClientset()
-	w := NewWatcher(client, cfg)
+	w := NewWatcher(client, nil, cfg)
 
 	err := w.executeScript(cfg.Resources.Labels[0].Script)
 	assert.NoError(t, err)
@@ -175,7 +228,7 @@ func TestWatcher_Start(t *testing.T) {
 	}
 
 	client := fake.NewSimpleClientset()
-	w := NewWatcher(client, cfg)
+	w := NewWatcher(client, nil, cfg)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
@@ -183,3 +236,328 @@ func TestWatcher_Start(t *testing.T) {
 	err := w.Start(ctx)
 	assert.NoError(t, err)
 }
+
+func TestWatcher_HandleResource_CustomResource(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-crd-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeCustomResource,
+			CustomResource: config.CustomResourceConfig{
+				Group:    "cert-manager.io",
+				Version:  "v1",
+				Resource: "certificates",
+				Kind:     "Certificate",
+			},
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetKind("Certificate")
+	cert.SetAPIVersion("cert-manager.io/v1")
+	cert.SetName("test-cert")
+	cert.SetNamespace("default")
+	cert.SetLabels(map[string]string{"app": "test"})
+
+	w.OnAdd(cert)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "default", "test-cert", "certificate.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "test-cert")
+}
+
+func TestWatcher_HandleResource_CustomResourceFields(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-crd-fields-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeCustomResource,
+			CustomResource: config.CustomResourceConfig{
+				Resources: []config.GVRConfig{
+					{
+						Group:    "cert-manager.io",
+						Version:  "v1",
+						Resource: "certificates",
+						Kind:     "Certificate",
+						Fields:   []string{"spec"},
+					},
+				},
+			},
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetKind("Certificate")
+	cert.SetAPIVersion("cert-manager.io/v1")
+	cert.SetName("test-cert")
+	cert.SetNamespace("default")
+	cert.SetLabels(map[string]string{"app": "test"})
+	require.NoError(t, unstructured.SetNestedField(cert.Object, "test-secret", "spec", "secretName"))
+
+	w.OnAdd(cert)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "default", "test-cert", "spec.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "test-secret")
+
+	_, err = os.ReadFile(filepath.Join(tempDir, "default", "test-cert", "certificate.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestWatcher_HandleResource_CustomResourceFields_DottedKey(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-crd-fields-dotted-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeCustomResource,
+			CustomResource: config.CustomResourceConfig{
+				Resources: []config.GVRConfig{
+					{
+						Group:    "cert-manager.io",
+						Version:  "v1",
+						Resource: "certificates",
+						Kind:     "Certificate",
+						Fields:   []string{"data.config.yaml"},
+					},
+				},
+			},
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetKind("Certificate")
+	cert.SetAPIVersion("cert-manager.io/v1")
+	cert.SetName("test-cert")
+	cert.SetNamespace("default")
+	cert.SetLabels(map[string]string{"app": "test"})
+	require.NoError(t, unstructured.SetNestedField(cert.Object, "key: value", "data", "config.yaml"))
+
+	w.OnAdd(cert)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "default", "test-cert", "config.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "key: value")
+}
+
+func TestWatcher_HandleResource_CustomResourceFields_DottedKeyCollision(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-crd-fields-dotted-collision-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeCustomResource,
+			CustomResource: config.CustomResourceConfig{
+				Resources: []config.GVRConfig{
+					{
+						Group:    "cert-manager.io",
+						Version:  "v1",
+						Resource: "certificates",
+						Kind:     "Certificate",
+						Fields:   []string{"data.config.yaml", "data.secrets.yaml"},
+					},
+				},
+			},
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetKind("Certificate")
+	cert.SetAPIVersion("cert-manager.io/v1")
+	cert.SetName("test-cert")
+	cert.SetNamespace("default")
+	cert.SetLabels(map[string]string{"app": "test"})
+	require.NoError(t, unstructured.SetNestedField(cert.Object, "CONFIGVAL", "data", "config.yaml"))
+	require.NoError(t, unstructured.SetNestedField(cert.Object, "SECRETVAL", "data", "secrets.yaml"))
+
+	w.OnAdd(cert)
+
+	configContent, err := os.ReadFile(filepath.Join(tempDir, "default", "test-cert", "config.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(configContent), "CONFIGVAL")
+
+	secretsContent, err := os.ReadFile(filepath.Join(tempDir, "default", "test-cert", "secrets.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(secretsContent), "SECRETVAL")
+}
+
+func TestWatcher_HandleResource_WarmStandby(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-standby-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeConfigMap,
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+	assert.True(t, w.IsLeading(), "a watcher with leader election unused should act by default")
+
+	w.SetLeading(false)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cm",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+		Data: map[string]string{"test.txt": "test content"},
+	}
+
+	require.NoError(t, w.OnAdd(cm))
+	_, err = os.ReadFile(filepath.Join(tempDir, "default", "test-cm", "test.txt"))
+	assert.True(t, os.IsNotExist(err), "standby replica should not write files")
+
+	w.SetLeading(true)
+	require.NoError(t, w.OnAdd(cm))
+	content, err := os.ReadFile(filepath.Join(tempDir, "default", "test-cm", "test.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "test content", string(content))
+}
+
+func TestWatcher_HandleResource_EmitsKubernetesEvent(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-event-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeConfigMap,
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cm",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+		Data: map[string]string{"test.txt": "test content"},
+	}
+
+	require.NoError(t, w.OnAdd(cm))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "create" && action.GetResource().Resource == "events" {
+				return
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected a Kubernetes Event to be recorded for the processed ConfigMap")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatcher_HandleResource_TemplateFanOut(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "watcher-template-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	tmplPath := filepath.Join(tempDir, "output.tmpl")
+	require.NoError(t, os.WriteFile(tmplPath, []byte("KEY={{ .Key }} VALUE={{ .Value }}"), 0644))
+
+	cfg := &config.Config{
+		Output: config.OutputConfig{
+			Folder: tempDir,
+			Template: config.TemplateConfig{
+				Path:             tmplPath,
+				FilenameTemplate: "{{ .Key }}.rendered",
+			},
+		},
+		Resources: config.ResourceConfig{
+			Type: config.ResourceTypeConfigMap,
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+			},
+			WatchConfig: config.WatchConfig{
+				IgnoreProcessed: true,
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	w := NewWatcher(client, nil, cfg)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cm",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+		Data: map[string]string{
+			"one.txt": "first",
+			"two.txt": "second",
+		},
+	}
+
+	require.NoError(t, w.OnAdd(cm))
+
+	for key, value := range cm.Data {
+		content, err := os.ReadFile(filepath.Join(tempDir, "default", "test-cm", key+".rendered"))
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("KEY=%s VALUE=%s", key, value), string(content))
+	}
+}
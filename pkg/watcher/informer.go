@@ -4,30 +4,54 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/label"
 	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+	"github.com/wasim-nihal/k8s-watcher/pkg/metrics"
+	"github.com/wasim-nihal/k8s-watcher/pkg/transform"
 )
 
+// queueEvent captures the event the worker pool needs to replay once a key
+// reaches the front of the workqueue
+type queueEvent struct {
+	action string
+	oldObj interface{}
+	newObj interface{}
+}
+
 // ResourceInformer manages the Kubernetes informer setup and event handling
 type ResourceInformer struct {
-	client     kubernetes.Interface
-	config     *config.ResourceConfig
-	handler    ResourceHandler
-	namespaces []string
-	stopCh     chan struct{}
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	config        *config.ResourceConfig
+	handler       ResourceHandler
+	namespaces    []string
+	labelSelector string
+	stopCh        chan struct{}
+
+	queue    workqueue.RateLimitingInterface
+	eventsMu sync.Mutex
+	events   map[string]queueEvent
 }
 
-// NewResourceInformer creates a new resource informer
-func NewResourceInformer(client kubernetes.Interface, cfg *config.ResourceConfig, handler ResourceHandler) *ResourceInformer {
+// NewResourceInformer creates a new resource informer. dynamicClient may be
+// nil unless cfg.Type is config.ResourceTypeCustomResource.
+func NewResourceInformer(client kubernetes.Interface, dynamicClient dynamic.Interface, namespace string, cfg *config.ResourceConfig, handler ResourceHandler) *ResourceInformer {
 	var namespaces []string
-	if cfg.ResourceNames != nil && len(cfg.ResourceNames) > 0 {
+	if len(cfg.ResourceNames) > 0 {
 		// Extract namespaces from resource names
 		nsMap := make(map[string]bool)
 		for _, name := range cfg.ResourceNames {
@@ -39,15 +63,43 @@ func NewResourceInformer(client kubernetes.Interface, cfg *config.ResourceConfig
 		for ns := range nsMap {
 			namespaces = append(namespaces, ns)
 		}
+	} else if namespace != "" {
+		namespaces = []string{namespace}
 	}
 
+	rateLimiter := workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second)
+
 	return &ResourceInformer{
-		client:     client,
-		config:     cfg,
-		handler:    handler,
-		namespaces: namespaces,
-		stopCh:     make(chan struct{}),
+		client:        client,
+		dynamicClient: dynamicClient,
+		config:        cfg,
+		handler:       handler,
+		namespaces:    namespaces,
+		labelSelector: labelSelectorString(cfg.Labels),
+		stopCh:        make(chan struct{}),
+		queue:         workqueue.NewRateLimitingQueue(rateLimiter),
+		events:        make(map[string]queueEvent),
+	}
+}
+
+// labelSelectorString returns the API-server-side label selector for cfgs,
+// to pre-narrow the watch stream before matchLabel's per-event check. When
+// GetSelector can't build one (e.g. an invalid MatchExpressions entry), the
+// watch stays unfiltered and every event is still checked client-side.
+func labelSelectorString(cfgs []config.LabelConfig) string {
+	selector, err := label.NewManager(cfgs).GetSelector()
+	if err != nil {
+		logger.Error("Failed to build label selector, watching unfiltered", "error", err)
+		return ""
 	}
+	return selector.String()
+}
+
+// tweakListOptions sets LabelSelector on LIST/WATCH requests so the API
+// server pre-narrows the stream to resources that can match at least one
+// configured label, per labelSelectorString.
+func (r *ResourceInformer) tweakListOptions(opts *metav1.ListOptions) {
+	opts.LabelSelector = r.labelSelector
 }
 
 // Start initializes and starts the informers
@@ -56,18 +108,26 @@ func (r *ResourceInformer) Start(ctx context.Context) error {
 		return r.handleListMode(ctx)
 	}
 
+	if r.config.Type == config.ResourceTypeCustomResource {
+		return r.startDynamicInformers(ctx)
+	}
+
 	// Set up informer factories for each namespace
 	for _, ns := range r.getNamespaces() {
 		factory := informers.NewSharedInformerFactoryWithOptions(
 			r.client,
 			time.Duration(r.config.WatchConfig.ServerTimeout)*time.Second,
 			informers.WithNamespace(ns),
+			informers.WithTweakListOptions(r.tweakListOptions),
 		)
 
 		switch r.config.Type {
 		case config.ResourceTypeConfigMap, config.ResourceTypeBoth:
 			informer := factory.Core().V1().ConfigMaps().Informer()
 			r.setupEventHandlers(informer, "ConfigMap")
+		case config.ResourceTypePodLogs:
+			informer := factory.Core().V1().Pods().Informer()
+			r.setupEventHandlers(informer, "Pod")
 		}
 
 		if r.config.Type == config.ResourceTypeSecret || r.config.Type == config.ResourceTypeBoth {
@@ -78,25 +138,148 @@ func (r *ResourceInformer) Start(ctx context.Context) error {
 		factory.Start(r.stopCh)
 	}
 
-	// Wait for context cancellation
+	return r.runUntilDone(ctx)
+}
+
+// startDynamicInformers sets up one dynamic informer per configured custom
+// resource GVR, sharing a single dynamicinformer factory (and thus a single
+// relist/resync loop) per watched namespace. A GVR with its own Namespace
+// override gets a dedicated factory scoped to just that namespace, instead
+// of following the informer's usual namespace set.
+func (r *ResourceInformer) startDynamicInformers(ctx context.Context) error {
+	shared, overridden := r.partitionGVRsByNamespace()
+
+	for _, entry := range overridden {
+		factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+			r.dynamicClient,
+			time.Duration(r.config.WatchConfig.ServerTimeout)*time.Second,
+			entry.namespace,
+			r.tweakListOptions,
+		)
+		informer := factory.ForResource(entry.gvr).Informer()
+		r.setupEventHandlers(informer, entry.kind)
+		factory.Start(r.stopCh)
+	}
+
+	if len(shared) > 0 {
+		for _, ns := range r.getNamespaces() {
+			factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+				r.dynamicClient,
+				time.Duration(r.config.WatchConfig.ServerTimeout)*time.Second,
+				ns,
+				r.tweakListOptions,
+			)
+
+			for _, entry := range shared {
+				informer := factory.ForResource(entry.gvr).Informer()
+				r.setupEventHandlers(informer, entry.kind)
+			}
+
+			factory.Start(r.stopCh)
+		}
+	}
+
+	return r.runUntilDone(ctx)
+}
+
+// partitionGVRsByNamespace splits the configured GVRs into those that
+// follow the informer's usual namespace set and those with their own
+// Namespace override
+func (r *ResourceInformer) partitionGVRsByNamespace() (shared, overridden []gvrEntry) {
+	for _, entry := range r.customResourceGVRs() {
+		if entry.namespace != "" {
+			overridden = append(overridden, entry)
+		} else {
+			shared = append(shared, entry)
+		}
+	}
+	return shared, overridden
+}
+
+// runUntilDone starts the configured number of workers draining the
+// workqueue and blocks until ctx is cancelled, then drains the queue and
+// stops the informer factories.
+func (r *ResourceInformer) runUntilDone(ctx context.Context) error {
+	workers := r.config.WatchConfig.Workers
+	if workers <= 0 {
+		workers = config.DefaultWorkers
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.runWorker()
+		}()
+	}
+
 	<-ctx.Done()
 	close(r.stopCh)
+	r.queue.ShutDown()
+	wg.Wait()
 	return nil
 }
 
-// handleListMode handles the LIST method of operation
+// gvrEntry pairs a GroupVersionResource with the Kind informers log it
+// under and its own Namespace/Fields overrides, if any
+type gvrEntry struct {
+	gvr       schema.GroupVersionResource
+	kind      string
+	namespace string
+	fields    []string
+}
+
+// customResourceGVRs builds the GroupVersionResource(s) for the configured custom resource(s)
+func (r *ResourceInformer) customResourceGVRs() []gvrEntry {
+	gvrs := r.config.CustomResource.GVRs()
+	entries := make([]gvrEntry, len(gvrs))
+	for i, gvr := range gvrs {
+		entries[i] = gvrEntry{
+			gvr:       schema.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource},
+			kind:      gvr.Kind,
+			namespace: gvr.Namespace,
+			fields:    gvr.Fields,
+		}
+	}
+	return entries
+}
+
+// handleListMode handles the LIST method of operation. Once every watched
+// namespace has been listed, handlers that implement listSyncer (Watcher, in
+// production) get a chance to garbage-collect output files whose source
+// object was not seen during this pass.
 func (r *ResourceInformer) handleListMode(ctx context.Context) error {
+	shared, overridden := r.partitionGVRsByNamespace()
+
 	for _, ns := range r.getNamespaces() {
-		if err := r.listResources(ctx, ns); err != nil {
+		if err := r.listResources(ctx, ns, shared); err != nil {
 			return err
 		}
 	}
+
+	for _, entry := range overridden {
+		if err := r.listCustomResources(ctx, entry.namespace, []gvrEntry{entry}); err != nil {
+			return err
+		}
+	}
+
+	if syncer, ok := r.handler.(listSyncer); ok {
+		return syncer.SyncListedNamespaces()
+	}
 	return nil
 }
 
-// listResources lists all matching resources in a namespace
-func (r *ResourceInformer) listResources(ctx context.Context, namespace string) error {
-	opts := metav1.ListOptions{}
+// listSyncer is implemented by handlers that can garbage-collect output
+// files left behind by resources no longer present in a completed LIST pass
+type listSyncer interface {
+	SyncListedNamespaces() error
+}
+
+// listResources lists configmaps, secrets and the given (namespace-sharing)
+// custom resource GVRs in a namespace
+func (r *ResourceInformer) listResources(ctx context.Context, namespace string, customGVRs []gvrEntry) error {
+	opts := metav1.ListOptions{LabelSelector: r.labelSelector}
 
 	if r.config.Type == config.ResourceTypeConfigMap || r.config.Type == config.ResourceTypeBoth {
 		cms, err := r.client.CoreV1().ConfigMaps(namespace).List(ctx, opts)
@@ -104,7 +287,9 @@ func (r *ResourceInformer) listResources(ctx context.Context, namespace string)
 			return fmt.Errorf("listing configmaps in namespace %s: %w", namespace, err)
 		}
 		for _, cm := range cms.Items {
-			r.handler.OnAdd(&cm)
+			if err := r.handler.OnAdd(&cm); err != nil {
+				logger.Error("Failed to handle listed configmap", "name", cm.Name, "namespace", cm.Namespace, "error", err)
+			}
 		}
 	}
 
@@ -114,24 +299,68 @@ func (r *ResourceInformer) listResources(ctx context.Context, namespace string)
 			return fmt.Errorf("listing secrets in namespace %s: %w", namespace, err)
 		}
 		for _, secret := range secrets.Items {
-			r.handler.OnAdd(&secret)
+			if err := r.handler.OnAdd(&secret); err != nil {
+				logger.Error("Failed to handle listed secret", "name", secret.Name, "namespace", secret.Namespace, "error", err)
+			}
+		}
+	}
+
+	if r.config.Type == config.ResourceTypeCustomResource {
+		if err := r.listCustomResources(ctx, namespace, customGVRs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listCustomResources lists each of the given GVRs in namespace
+func (r *ResourceInformer) listCustomResources(ctx context.Context, namespace string, entries []gvrEntry) error {
+	opts := metav1.ListOptions{LabelSelector: r.labelSelector}
+
+	for _, entry := range entries {
+		list, err := r.dynamicClient.Resource(entry.gvr).Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("listing %s in namespace %s: %w", entry.gvr.Resource, namespace, err)
+		}
+		for i := range list.Items {
+			if err := r.handler.OnAdd(&list.Items[i]); err != nil {
+				logger.Error("Failed to handle listed custom resource", "name", list.Items[i].GetName(), "namespace", list.Items[i].GetNamespace(), "error", err)
+			}
 		}
 	}
 
 	return nil
 }
 
-// setupEventHandlers configures the event handlers for the informer
+// setupEventHandlers configures the event handlers for the informer. Handlers
+// only enqueue a namespaced key; the actual write/script/webhook actions run
+// on the worker pool started by runUntilDone so a slow webhook can never
+// stall the informer's event loop. Before enqueueing, obj runs through the
+// configured transform pipeline (pkg/transform), which can drop the event
+// outright or relabel annotations consumed later by file.GetAnnotationPath.
 func (r *ResourceInformer) setupEventHandlers(informer cache.SharedIndexInformer, resourceType string) {
 	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			r.handler.OnAdd(obj)
+			copied, keep := r.transform(obj)
+			if !keep {
+				return
+			}
+			r.enqueue(copied, queueEvent{action: "Added", newObj: copied})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
-			r.handler.OnUpdate(oldObj, newObj)
+			copied, keep := r.transform(newObj)
+			if !keep {
+				return
+			}
+			r.enqueue(copied, queueEvent{action: "Updated", oldObj: oldObj, newObj: copied})
 		},
 		DeleteFunc: func(obj interface{}) {
-			r.handler.OnDelete(obj)
+			copied, keep := r.transform(obj)
+			if !keep {
+				return
+			}
+			r.enqueue(copied, queueEvent{action: "Deleted", newObj: copied})
 		},
 	})
 
@@ -140,6 +369,142 @@ func (r *ResourceInformer) setupEventHandlers(informer cache.SharedIndexInformer
 	)
 }
 
+// transform runs the configured transform pipeline against a deep copy of
+// obj, returning the object callers should enqueue and whether the event
+// should be kept. obj is never mutated in place: it is owned by the
+// SharedIndexInformer's store/DeltaFIFO, and client-go requires consumers
+// treat it as read-only. Objects that don't implement runtime.Object (so
+// they can't be copied) or metav1.Object (so transform.Apply can't inspect
+// them) are passed through untouched, as is every event when no transforms
+// are configured.
+func (r *ResourceInformer) transform(obj interface{}) (interface{}, bool) {
+	if len(r.config.Transforms) == 0 {
+		return obj, true
+	}
+
+	robj, ok := obj.(runtime.Object)
+	if !ok {
+		return obj, true
+	}
+	copied := robj.DeepCopyObject()
+
+	metaObj, ok := copied.(metav1.Object)
+	if !ok {
+		return obj, true
+	}
+
+	return copied, transform.Apply(metaObj, r.config.Transforms)
+}
+
+// enqueue records the latest event for a key and adds it to the workqueue
+func (r *ResourceInformer) enqueue(obj interface{}, event queueEvent) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Error("Failed to compute key for resource event", "error", err)
+		return
+	}
+
+	r.eventsMu.Lock()
+	r.events[key] = event
+	r.eventsMu.Unlock()
+
+	r.queue.Add(key)
+	metrics.SetQueueDepth(r.queue.Len())
+}
+
+// runWorker pops keys off the workqueue until it is shut down, replaying the
+// associated event and applying the configured retry/backoff policy
+func (r *ResourceInformer) runWorker() {
+	for r.processNextItem() {
+	}
+}
+
+// processNextItem handles a single workqueue item, returning false once the
+// queue has been shut down
+func (r *ResourceInformer) processNextItem() bool {
+	key, shutdown := r.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer r.queue.Done(key)
+	defer func() { metrics.SetQueueDepth(r.queue.Len()) }()
+
+	err := r.sync(key.(string))
+	switch {
+	case err == nil:
+		r.queue.Forget(key)
+	case r.queue.NumRequeues(key) < r.maxRetries():
+		logger.Warn("Retrying resource event after error",
+			"key", key,
+			"retries", r.queue.NumRequeues(key)+1,
+			"queueDepth", r.queue.Len(),
+			"error", err,
+		)
+		r.queue.AddRateLimited(key)
+	default:
+		logger.Error("Dropping resource event after max retries",
+			"key", key,
+			"retries", r.queue.NumRequeues(key),
+			"error", err,
+		)
+		r.queue.Forget(key)
+	}
+
+	return true
+}
+
+// sync looks up the event recorded for key and dispatches it to the handler.
+// The event is only removed from r.events once the handler succeeds, so
+// processNextItem's AddRateLimited retry replays the same event instead of
+// finding the map empty and mistaking a failed attempt for success. The
+// delete is conditional on r.events[key] still being the event we just
+// processed, since a newer event can overwrite the map entry while this one
+// is in flight (the workqueue never runs two workers on the same key
+// concurrently, but it can queue a fresher event behind the one we're
+// handling) — deleting unconditionally would drop that fresher event.
+func (r *ResourceInformer) sync(key string) error {
+	r.eventsMu.Lock()
+	event, ok := r.events[key]
+	r.eventsMu.Unlock()
+
+	if !ok {
+		// A newer event for this key was already processed.
+		return nil
+	}
+
+	var err error
+	switch event.action {
+	case "Added":
+		err = r.handler.OnAdd(event.newObj)
+	case "Updated":
+		err = r.handler.OnUpdate(event.oldObj, event.newObj)
+	case "Deleted":
+		err = r.handler.OnDelete(event.newObj)
+	default:
+		err = fmt.Errorf("unknown queue event action: %s", event.action)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	r.eventsMu.Lock()
+	if r.events[key] == event {
+		delete(r.events, key)
+	}
+	r.eventsMu.Unlock()
+
+	return nil
+}
+
+// maxRetries returns the configured retry budget, falling back to the default
+func (r *ResourceInformer) maxRetries() int {
+	if r.config.WatchConfig.MaxRetries <= 0 {
+		return config.DefaultMaxRetries
+	}
+	return r.config.WatchConfig.MaxRetries
+}
+
 // getNamespaces returns the list of namespaces to watch
 func (r *ResourceInformer) getNamespaces() []string {
 	if len(r.namespaces) > 0 {
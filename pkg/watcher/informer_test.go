@@ -2,13 +2,20 @@ package watcher
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/wasim-nihal/k8s-watcher/pkg/config"
@@ -19,16 +26,19 @@ type MockResourceHandler struct {
 	mock.Mock
 }
 
-func (m *MockResourceHandler) OnAdd(obj interface{}) {
-	m.Called(obj)
+func (m *MockResourceHandler) OnAdd(obj interface{}) error {
+	args := m.Called(obj)
+	return args.Error(0)
 }
 
-func (m *MockResourceHandler) OnUpdate(oldObj, newObj interface{}) {
-	m.Called(oldObj, newObj)
+func (m *MockResourceHandler) OnUpdate(oldObj, newObj interface{}) error {
+	args := m.Called(oldObj, newObj)
+	return args.Error(0)
 }
 
-func (m *MockResourceHandler) OnDelete(obj interface{}) {
-	m.Called(obj)
+func (m *MockResourceHandler) OnDelete(obj interface{}) error {
+	args := m.Called(obj)
+	return args.Error(0)
 }
 
 func TestNewResourceInformer(t *testing.T) {
@@ -39,11 +49,41 @@ func TestNewResourceInformer(t *testing.T) {
 	}
 	handler := &MockResourceHandler{}
 
-	informer := NewResourceInformer(client, "default", cfg, handler)
+	informer := NewResourceInformer(client, nil, "default", cfg, handler)
 	assert.NotNil(t, informer)
 	assert.Equal(t, []string{"default"}, informer.namespaces)
 }
 
+func TestResourceInformer_LabelSelector(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	handler := &MockResourceHandler{}
+
+	t.Run("single label config narrows server-side", func(t *testing.T) {
+		cfg := &config.ResourceConfig{
+			Type:   config.ResourceTypeConfigMap,
+			Labels: []config.LabelConfig{{Name: "app", Value: "test"}},
+		}
+		informer := NewResourceInformer(client, nil, "default", cfg, handler)
+		assert.Equal(t, "app=test", informer.labelSelector)
+
+		opts := metav1.ListOptions{}
+		informer.tweakListOptions(&opts)
+		assert.Equal(t, "app=test", opts.LabelSelector)
+	})
+
+	t.Run("multiple label configs fall back to unfiltered", func(t *testing.T) {
+		cfg := &config.ResourceConfig{
+			Type: config.ResourceTypeConfigMap,
+			Labels: []config.LabelConfig{
+				{Name: "app", Value: "test"},
+				{Name: "team", Value: "infra"},
+			},
+		}
+		informer := NewResourceInformer(client, nil, "default", cfg, handler)
+		assert.Equal(t, "", informer.labelSelector)
+	})
+}
+
 func TestResourceInformer_ListMode(t *testing.T) {
 	client := fake.NewSimpleClientset()
 	handler := &MockResourceHandler{}
@@ -66,9 +106,9 @@ func TestResourceInformer_ListMode(t *testing.T) {
 		Method: config.WatchMethodList,
 	}
 
-	handler.On("OnAdd", mock.Anything).Return()
+	handler.On("OnAdd", mock.Anything).Return(nil)
 
-	informer := NewResourceInformer(client, "default", cfg, handler)
+	informer := NewResourceInformer(client, nil, "default", cfg, handler)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
@@ -91,11 +131,11 @@ func TestResourceInformer_WatchMode(t *testing.T) {
 		},
 	}
 
-	handler.On("OnAdd", mock.Anything).Return()
-	handler.On("OnUpdate", mock.Anything, mock.Anything).Return()
-	handler.On("OnDelete", mock.Anything).Return()
+	handler.On("OnAdd", mock.Anything).Return(nil)
+	handler.On("OnUpdate", mock.Anything, mock.Anything).Return(nil)
+	handler.On("OnDelete", mock.Anything).Return(nil)
 
-	informer := NewResourceInformer(client, "default", cfg, handler)
+	informer := NewResourceInformer(client, nil, "default", cfg, handler)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
@@ -129,6 +169,60 @@ func TestResourceInformer_WatchMode(t *testing.T) {
 	handler.AssertNumberOfCalls(t, "OnAdd", 1)
 }
 
+func TestResourceInformer_RetriesFailedHandlerUntilSuccess(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	handler := &MockResourceHandler{}
+
+	cfg := &config.ResourceConfig{
+		Type:   config.ResourceTypeConfigMap,
+		Method: config.WatchMethodWatch,
+		WatchConfig: config.WatchConfig{
+			ServerTimeout: 30,
+			MaxRetries:    5,
+		},
+	}
+
+	var calls int32
+	countCall := func(mock.Arguments) { atomic.AddInt32(&calls, 1) }
+	handler.On("OnAdd", mock.Anything).Run(countCall).Return(errors.New("transient failure")).Twice()
+	handler.On("OnAdd", mock.Anything).Run(countCall).Return(nil)
+
+	informer := NewResourceInformer(client, nil, "default", cfg, handler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = informer.Start(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cm",
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			"test.txt": "test content",
+		},
+	}
+	_, err := client.CoreV1().ConfigMaps("default").Create(context.Background(), cm, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) >= 3
+	}, time.Second, 5*time.Millisecond, "handler should be retried after failing until it succeeds")
+
+	// Cancel and wait for the worker goroutine to exit before inspecting
+	// handler.Calls, so nothing is still writing to the mock while we read it.
+	cancel()
+	<-done
+	handler.AssertNumberOfCalls(t, "OnAdd", 3)
+}
+
 func TestResourceInformer_Stop(t *testing.T) {
 	client := fake.NewSimpleClientset()
 	handler := &MockResourceHandler{}
@@ -137,7 +231,7 @@ func TestResourceInformer_Stop(t *testing.T) {
 		Type: config.ResourceTypeConfigMap,
 	}
 
-	informer := NewResourceInformer(client, "default", cfg, handler)
+	informer := NewResourceInformer(client, nil, "default", cfg, handler)
 	informer.Stop()
 
 	// Verify the stop channel is closed
@@ -159,7 +253,7 @@ func TestResourceInformer_GetNamespaces(t *testing.T) {
 		ResourceNames: []string{"ns1/cm1", "ns2/cm2"},
 	}
 
-	informer := NewResourceInformer(client, "default", cfg, handler)
+	informer := NewResourceInformer(client, nil, "default", cfg, handler)
 	namespaces := informer.getNamespaces()
 	assert.ElementsMatch(t, []string{"ns1", "ns2"}, namespaces)
 
@@ -168,7 +262,94 @@ func TestResourceInformer_GetNamespaces(t *testing.T) {
 		Type: config.ResourceTypeConfigMap,
 	}
 
-	informer = NewResourceInformer(client, "", cfg, handler)
+	informer = NewResourceInformer(client, nil, "", cfg, handler)
 	namespaces = informer.getNamespaces()
 	assert.Equal(t, []string{metav1.NamespaceAll}, namespaces)
 }
+
+func TestResourceInformer_CustomResourceListMode(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	gvk := schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{gvr: "CertificateList"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(gvk)
+	cert.SetName("test-cert")
+	cert.SetNamespace("default")
+
+	_, err := dynamicClient.Resource(gvr).Namespace("default").Create(context.Background(), cert, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	handler := &MockResourceHandler{}
+	handler.On("OnAdd", mock.Anything).Return(nil)
+
+	cfg := &config.ResourceConfig{
+		Type:   config.ResourceTypeCustomResource,
+		Method: config.WatchMethodList,
+		CustomResource: config.CustomResourceConfig{
+			Group:    "cert-manager.io",
+			Version:  "v1",
+			Resource: "certificates",
+			Kind:     "Certificate",
+		},
+	}
+
+	informer := NewResourceInformer(nil, dynamicClient, "default", cfg, handler)
+
+	err = informer.Start(context.Background())
+	assert.NoError(t, err)
+
+	handler.AssertCalled(t, "OnAdd", mock.Anything)
+}
+
+func TestResourceInformer_CustomResourceListMode_MultipleGVRs(t *testing.T) {
+	pipelineRunGVR := schema.GroupVersionResource{Group: "tekton.dev", Version: "v1beta1", Resource: "pipelineruns"}
+	pipelineRunGVK := schema.GroupVersionKind{Group: "tekton.dev", Version: "v1beta1", Kind: "PipelineRun"}
+	applicationGVR := schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+	applicationGVK := schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Application"}
+
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{
+		pipelineRunGVR: "PipelineRunList",
+		applicationGVR: "ApplicationList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds)
+
+	pipelineRun := &unstructured.Unstructured{}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	pipelineRun.SetName("test-pipelinerun")
+	pipelineRun.SetNamespace("default")
+	_, err := dynamicClient.Resource(pipelineRunGVR).Namespace("default").Create(context.Background(), pipelineRun, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	application := &unstructured.Unstructured{}
+	application.SetGroupVersionKind(applicationGVK)
+	application.SetName("test-application")
+	application.SetNamespace("default")
+	_, err = dynamicClient.Resource(applicationGVR).Namespace("default").Create(context.Background(), application, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	handler := &MockResourceHandler{}
+	handler.On("OnAdd", mock.Anything).Return(nil)
+
+	cfg := &config.ResourceConfig{
+		Type:   config.ResourceTypeCustomResource,
+		Method: config.WatchMethodList,
+		CustomResource: config.CustomResourceConfig{
+			Resources: []config.GVRConfig{
+				{Group: "tekton.dev", Version: "v1beta1", Resource: "pipelineruns", Kind: "PipelineRun"},
+				{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications", Kind: "Application"},
+			},
+		},
+	}
+
+	informer := NewResourceInformer(nil, dynamicClient, "default", cfg, handler)
+
+	err = informer.Start(context.Background())
+	assert.NoError(t, err)
+
+	handler.AssertNumberOfCalls(t, "OnAdd", 2)
+}
@@ -0,0 +1,225 @@
+package watcher
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/file"
+	"github.com/wasim-nihal/k8s-watcher/pkg/label"
+	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+)
+
+const maxReconnectBackoff = 30 * time.Second
+
+// podLogStreamer tails matching pods' container logs to rotating files
+// under the configured output folder, reconnecting with backoff when the
+// apiserver closes the log stream. Pods are matched with the same
+// label.Manager used for every other resource type.
+type podLogStreamer struct {
+	client       kubernetes.Interface
+	labelManager *label.Manager
+	fileHandler  *file.Handler
+	config       config.PodLogsConfig
+
+	// reconnectBackoff is the initial delay before re-establishing a
+	// dropped stream, taken from WatchConfig.ErrorThrottleTime so pod-log
+	// streams back off at the same rate as every other watch error; it
+	// then doubles on each consecutive failure up to maxReconnectBackoff.
+	reconnectBackoff time.Duration
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc // keyed by namespace/pod/container
+
+	sem chan struct{} // caps concurrent streams open against the apiserver
+}
+
+// newPodLogStreamer creates a streamer for cfg.Type == config.ResourceTypePodLogs
+func newPodLogStreamer(client kubernetes.Interface, labelManager *label.Manager, fileHandler *file.Handler, cfg config.PodLogsConfig, watchCfg config.WatchConfig) *podLogStreamer {
+	maxConcurrent := cfg.MaxConcurrentStreams
+	if maxConcurrent <= 0 {
+		maxConcurrent = config.DefaultMaxLogStreams
+	}
+
+	reconnectBackoff := time.Duration(watchCfg.ErrorThrottleTime) * time.Second
+	if reconnectBackoff <= 0 {
+		reconnectBackoff = time.Second
+	}
+
+	return &podLogStreamer{
+		client:           client,
+		labelManager:     labelManager,
+		fileHandler:      fileHandler,
+		config:           cfg,
+		reconnectBackoff: reconnectBackoff,
+		cancels:          make(map[string]context.CancelFunc),
+		sem:              make(chan struct{}, maxConcurrent),
+	}
+}
+
+// handlePodAdd attaches a log stream to every matching container of a
+// newly-observed pod
+func (s *podLogStreamer) handlePodAdd(pod *corev1.Pod) error {
+	if len(s.labelManager.MatchLabels(pod.Labels)) == 0 {
+		return nil
+	}
+
+	for _, container := range s.containers(pod) {
+		s.startStream(pod.Namespace, pod.Name, container)
+	}
+
+	return nil
+}
+
+// handlePodDelete cancels every log stream goroutine attached to a pod
+func (s *podLogStreamer) handlePodDelete(pod *corev1.Pod) error {
+	for _, container := range s.containers(pod) {
+		s.stopStream(pod.Namespace, pod.Name, container)
+	}
+	return nil
+}
+
+// containers returns the container names a pod should be streamed for
+func (s *podLogStreamer) containers(pod *corev1.Pod) []string {
+	if s.config.Container != "" {
+		return []string{s.config.Container}
+	}
+
+	names := make([]string, 0, len(pod.Spec.Containers))
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func streamKey(namespace, pod, container string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, pod, container)
+}
+
+// startStream launches the stream goroutine for a container, if one isn't
+// already running
+func (s *podLogStreamer) startStream(namespace, pod, container string) {
+	key := streamKey(namespace, pod, container)
+
+	s.mu.Lock()
+	if _, exists := s.cancels[key]; exists {
+		s.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancels[key] = cancel
+	s.mu.Unlock()
+
+	go s.runStream(ctx, namespace, pod, container)
+}
+
+// stopStream cancels the stream goroutine for a container, if one is running
+func (s *podLogStreamer) stopStream(namespace, pod, container string) {
+	key := streamKey(namespace, pod, container)
+
+	s.mu.Lock()
+	cancel, exists := s.cancels[key]
+	delete(s.cancels, key)
+	s.mu.Unlock()
+
+	if exists {
+		cancel()
+	}
+}
+
+// runStream tails a single container's logs, reconnecting with exponential
+// backoff until ctx is cancelled (the pod was deleted)
+func (s *podLogStreamer) runStream(ctx context.Context, namespace, pod, container string) {
+	path := filepath.Join(s.fileHandler.GetDefaultPath(), namespace, pod, container+".log")
+	backoff := s.reconnectBackoff
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case s.sem <- struct{}{}:
+		}
+
+		start := time.Now()
+		err := s.tail(ctx, namespace, pod, container, path)
+		<-s.sem
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A clean end, or a stream that ran long enough to prove the
+		// container is healthy, means the previous failures are behind us:
+		// reset backoff so an occasional restart doesn't ratchet the
+		// reconnect delay up to maxReconnectBackoff and leave it there for
+		// the life of the watcher process.
+		if err == nil || time.Since(start) >= maxReconnectBackoff {
+			backoff = s.reconnectBackoff
+		}
+
+		if err != nil {
+			logger.Warn("Pod log stream ended, reconnecting",
+				"namespace", namespace, "pod", pod, "container", container,
+				"error", err, "backoff", backoff,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// tail opens one GetLogs stream and appends every line to the rotating
+// output file until the stream ends
+func (s *podLogStreamer) tail(ctx context.Context, namespace, pod, container, path string) error {
+	opts := &corev1.PodLogOptions{
+		Follow:    true,
+		Container: container,
+		Previous:  s.config.Previous,
+	}
+	if s.config.SinceSeconds > 0 {
+		since := s.config.SinceSeconds
+		opts.SinceSeconds = &since
+	}
+	if s.config.TailLines > 0 {
+		tail := s.config.TailLines
+		opts.TailLines = &tail
+	}
+
+	stream, err := s.client.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("opening log stream: %w", err)
+	}
+	defer stream.Close()
+
+	writer, err := file.NewRotatingWriter(path, s.config.MaxSizeBytes, s.config.MaxAgeSeconds)
+	if err != nil {
+		return fmt.Errorf("opening log output: %w", err)
+	}
+	defer writer.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if _, err := writer.WriteLine(scanner.Bytes()); err != nil {
+			return fmt.Errorf("writing log line: %w", err)
+		}
+	}
+
+	return scanner.Err()
+}
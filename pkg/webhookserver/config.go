@@ -0,0 +1,137 @@
+package webhookserver
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+)
+
+// Config configures the webhook receiver server
+type Config struct {
+	ListenAddr   string               `yaml:"listenAddr"`
+	Path         string               `yaml:"path"`
+	MaxBodyBytes int64                `yaml:"maxBodyBytes"`
+	TLS          TLSConfig            `yaml:"tls"`
+	Auth         AuthConfig           `yaml:"auth"`
+	Logging      config.LoggingConfig `yaml:"logging"`
+}
+
+// TLSConfig configures the server's listening certificate and, optionally,
+// the client CA used for mutual TLS
+type TLSConfig struct {
+	CertFile     string `yaml:"certFile"`
+	KeyFile      string `yaml:"keyFile"`
+	ClientCAFile string `yaml:"clientCAFile"`
+}
+
+// AuthConfig selects and configures the webhook authentication mode
+type AuthConfig struct {
+	Mode   string           `yaml:"mode"`
+	Basic  BasicAuthConfig  `yaml:"basic"`
+	Bearer BearerAuthConfig `yaml:"bearer"`
+	HMAC   HMACAuthConfig   `yaml:"hmac"`
+}
+
+// BasicAuthConfig holds the expected username/password for AuthModeBasic
+type BasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// BearerAuthConfig holds the expected bearer token for AuthModeBearer
+type BearerAuthConfig struct {
+	Token string `yaml:"token"`
+}
+
+// HMACAuthConfig holds the shared secret and signature header for AuthModeHMAC
+type HMACAuthConfig struct {
+	Secret string `yaml:"secret"`
+	Header string `yaml:"header"`
+}
+
+// Supported auth modes and defaults
+const (
+	AuthModeBasic  = "basic"
+	AuthModeBearer = "bearer"
+	AuthModeHMAC   = "hmac-sha256"
+	AuthModeNone   = "none"
+
+	DefaultListenAddr   = ":8080"
+	DefaultPath         = "/webhook"
+	DefaultMaxBodyBytes = 1 << 20 // 1MiB
+	DefaultHMACHeader   = "X-Hub-Signature-256"
+)
+
+// LoadConfig reads and defaults a webhookserver Config from a YAML file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+
+	if err := validateConfig(&cfg); err != nil {
+		return nil, fmt.Errorf("validating config: %w", err)
+	}
+
+	setConfigDefaults(&cfg)
+	return &cfg, nil
+}
+
+func validateConfig(cfg *Config) error {
+	switch cfg.Auth.Mode {
+	case AuthModeBasic:
+		if cfg.Auth.Basic.Username == "" || cfg.Auth.Basic.Password == "" {
+			return fmt.Errorf("auth.basic.username and auth.basic.password are required for auth mode %q", AuthModeBasic)
+		}
+	case AuthModeBearer:
+		if cfg.Auth.Bearer.Token == "" {
+			return fmt.Errorf("auth.bearer.token is required for auth mode %q", AuthModeBearer)
+		}
+	case AuthModeHMAC:
+		if cfg.Auth.HMAC.Secret == "" {
+			return fmt.Errorf("auth.hmac.secret is required for auth mode %q", AuthModeHMAC)
+		}
+	case AuthModeNone, "":
+		// Valid mode
+	default:
+		return fmt.Errorf("invalid auth mode: %s", cfg.Auth.Mode)
+	}
+
+	if (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") {
+		return fmt.Errorf("tls.certFile and tls.keyFile must be set together")
+	}
+
+	return nil
+}
+
+func setConfigDefaults(cfg *Config) {
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = DefaultListenAddr
+	}
+	if cfg.Path == "" {
+		cfg.Path = DefaultPath
+	}
+	if cfg.MaxBodyBytes == 0 {
+		cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if cfg.Auth.HMAC.Header == "" {
+		cfg.Auth.HMAC.Header = DefaultHMACHeader
+	}
+	if cfg.Logging.Level == "" {
+		cfg.Logging.Level = config.DefaultLogLevel
+	}
+	if cfg.Logging.Format == "" {
+		cfg.Logging.Format = config.DefaultLogFormat
+	}
+	if cfg.Logging.Timezone == "" {
+		cfg.Logging.Timezone = config.DefaultLogTimezone
+	}
+}
@@ -0,0 +1,203 @@
+package webhookserver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+)
+
+// Server receives webhook callbacks over HTTP(S) with pluggable
+// authentication, request-size limiting and a /healthz endpoint
+type Server struct {
+	config Config
+	mux    *http.ServeMux
+	http   *http.Server
+}
+
+// NewServer creates a webhook receiver from the given configuration
+func NewServer(cfg Config) *Server {
+	s := &Server{config: cfg}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc(cfg.Path, s.handleWebhook)
+	s.mux.HandleFunc("/healthz", s.handleHealthz)
+
+	s.http = &http.Server{
+		Addr:    cfg.ListenAddr,
+		Handler: s.mux,
+	}
+
+	return s
+}
+
+// Handler returns the server's HTTP handler, primarily for tests that want
+// to exercise request handling via httptest without binding a real listener
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe starts the server, blocking until ctx is cancelled or the
+// server fails to start. TLS (optionally mTLS) is used when
+// cfg.TLS.CertFile is set.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		var err error
+		if s.config.TLS.CertFile != "" {
+			tlsConfig, tlsErr := s.buildTLSConfig()
+			if tlsErr != nil {
+				errCh <- tlsErr
+				return
+			}
+			s.http.TLSConfig = tlsConfig
+			err = s.http.ListenAndServeTLS(s.config.TLS.CertFile, s.config.TLS.KeyFile)
+		} else {
+			err = s.http.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return s.http.Shutdown(context.Background())
+	case err := <-errCh:
+		return err
+	}
+}
+
+// buildTLSConfig sets up client-CA verification for mTLS when configured
+func (s *Server) buildTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if s.config.TLS.ClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	caCert, err := os.ReadFile(s.config.TLS.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading client CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("parsing client CA file: no certificates found")
+	}
+
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return tlsConfig, nil
+}
+
+// handleHealthz reports liveness for readiness/liveness probes
+func (s *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Warn("Failed to read webhook body", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if !s.authenticate(r, body) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="k8s-watcher"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	logger.Info("Received webhook",
+		"remoteAddr", r.RemoteAddr,
+		"size", len(body),
+	)
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// authenticate validates the request against the configured auth mode
+func (s *Server) authenticate(r *http.Request, body []byte) bool {
+	switch s.config.Auth.Mode {
+	case AuthModeBasic:
+		return s.authenticateBasic(r)
+	case AuthModeBearer:
+		return s.authenticateBearer(r)
+	case AuthModeHMAC:
+		return s.authenticateHMAC(r, body)
+	case AuthModeNone, "":
+		return true
+	default:
+		logger.Error("Unknown webhook auth mode", "mode", s.config.Auth.Mode)
+		return false
+	}
+}
+
+func (s *Server) authenticateBasic(r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	userMatch := subtle.ConstantTimeCompare([]byte(username), []byte(s.config.Auth.Basic.Username)) == 1
+	passMatch := subtle.ConstantTimeCompare([]byte(password), []byte(s.config.Auth.Basic.Password)) == 1
+	return userMatch && passMatch
+}
+
+func (s *Server) authenticateBearer(r *http.Request) bool {
+	authHeader := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.config.Auth.Bearer.Token)) == 1
+}
+
+// authenticateHMAC verifies a GitHub-style X-Hub-Signature-256 header by
+// computing HMAC-SHA256(secret, body) over the already-read raw body and
+// comparing with hmac.Equal to avoid timing leaks. The signature may be
+// hex- or base64-encoded and may optionally carry a "sha256=" prefix.
+func (s *Server) authenticateHMAC(r *http.Request, body []byte) bool {
+	signature := strings.TrimPrefix(r.Header.Get(s.config.Auth.HMAC.Header), "sha256=")
+	if signature == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.config.Auth.HMAC.Secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	if decoded, err := hex.DecodeString(signature); err == nil {
+		return hmac.Equal(decoded, expected)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(signature); err == nil {
+		return hmac.Equal(decoded, expected)
+	}
+
+	return false
+}
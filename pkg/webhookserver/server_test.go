@@ -0,0 +1,112 @@
+package webhookserver_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wasim-nihal/k8s-watcher/pkg/config"
+	"github.com/wasim-nihal/k8s-watcher/pkg/logger"
+	"github.com/wasim-nihal/k8s-watcher/pkg/webhookserver"
+)
+
+func init() {
+	_ = logger.Initialize(config.LoggingConfig{Level: "INFO", Format: "JSON"})
+}
+
+func newTestServer(cfg webhookserver.Config) *httptest.Server {
+	srv := webhookserver.NewServer(cfg)
+	return httptest.NewServer(srv.Handler())
+}
+
+func TestServer_Webhook_BasicAuth(t *testing.T) {
+	cfg := webhookserver.Config{
+		Path: "/webhook",
+		Auth: webhookserver.AuthConfig{
+			Mode:  webhookserver.AuthModeBasic,
+			Basic: webhookserver.BasicAuthConfig{Username: "admin", Password: "secret"},
+		},
+		MaxBodyBytes: 1 << 20,
+	}
+
+	ts := newTestServer(cfg)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/webhook", strings.NewReader("{}"))
+	req.SetBasicAuth("admin", "wrong")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 for bad credentials, got %d", resp.StatusCode)
+	}
+
+	req2, _ := http.NewRequest(http.MethodPost, ts.URL+"/webhook", strings.NewReader("{}"))
+	req2.SetBasicAuth("admin", "secret")
+	resp2, err := http.DefaultClient.Do(req2)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp2.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 for valid credentials, got %d", resp2.StatusCode)
+	}
+}
+
+func TestServer_Webhook_HMAC(t *testing.T) {
+	secret := "s3cr3t"
+	body := `{"event":"update"}`
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	cfg := webhookserver.Config{
+		Path: "/webhook",
+		Auth: webhookserver.AuthConfig{
+			Mode: webhookserver.AuthModeHMAC,
+			HMAC: webhookserver.HMACAuthConfig{Secret: secret, Header: webhookserver.DefaultHMACHeader},
+		},
+		MaxBodyBytes: 1 << 20,
+	}
+
+	ts := newTestServer(cfg)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/webhook", strings.NewReader(body))
+	req.Header.Set(webhookserver.DefaultHMACHeader, signature)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 for valid signature, got %d", resp.StatusCode)
+	}
+
+	req2, _ := http.NewRequest(http.MethodPost, ts.URL+"/webhook", strings.NewReader(body))
+	req2.Header.Set(webhookserver.DefaultHMACHeader, "sha256=deadbeef")
+	resp2, err := http.DefaultClient.Do(req2)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp2.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 for invalid signature, got %d", resp2.StatusCode)
+	}
+}
+
+func TestServer_Healthz(t *testing.T) {
+	ts := newTestServer(webhookserver.Config{Path: "/webhook", MaxBodyBytes: 1 << 20})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 from /healthz, got %d", resp.StatusCode)
+	}
+}